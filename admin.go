@@ -0,0 +1,185 @@
+package inject
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type adminService struct {
+	Type         string                 `json:"type"`
+	Lifecycle    string                 `json:"lifecycle"`
+	Description  string                 `json:"description,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Dependencies []string               `json:"dependencies,omitempty"`
+}
+
+type adminStats struct {
+	Type        string `json:"type"`
+	Lifecycle   string `json:"lifecycle"`
+	Created     uint64 `json:"created"`
+	Failures    uint64 `json:"failures"`
+	LastError   string `json:"lastError,omitempty"`
+	Degraded    bool   `json:"degraded,omitempty"`
+	Waits       uint64 `json:"waits,omitempty"`
+	WaitTimeMs  int64  `json:"waitTimeMs,omitempty"`
+	ApproxBytes uint64 `json:"approxBytes,omitempty"`
+}
+
+type adminEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type adminResult struct {
+	Type     string   `json:"type,omitempty"`
+	OK       bool     `json:"ok"`
+	Degraded bool     `json:"degraded,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// AdminHandler serves a JSON admin API for the container:
+//
+//	GET  /services            registrations with their dependencies
+//	GET  /stats               factory statistics
+//	GET  /graph               dependency edges
+//	GET  /health              HealthCheck results, 503 if any failed
+//	POST /warmup[?tag=name]   build singletons, optionally by tag
+//	POST /evict?type=*db.Pool drop a cached singleton
+//
+// Every request must pass authorize; a nil authorize rejects them all.
+// Mount it under a prefix with http.StripPrefix.
+func AdminHandler(container *Container, authorize func(r *http.Request) bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /services", container.adminServices)
+	mux.HandleFunc("GET /stats", container.adminStats)
+	mux.HandleFunc("GET /graph", container.adminGraph)
+	mux.HandleFunc("GET /health", container.adminHealth)
+	mux.HandleFunc("POST /warmup", container.adminWarmup)
+	mux.HandleFunc("POST /evict", container.adminEvict)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorize == nil || !authorize(r) {
+			writeJSON(w, http.StatusUnauthorized, adminResult{Error: "unauthorized"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (c *Container) adminServices(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	services := []adminService{}
+	for _, descriptor := range c.sortedDescriptors() {
+		service := adminService{
+			Type:        descriptor.displayName(),
+			Lifecycle:   descriptor.Lifecycle.String(),
+			Description: descriptor.Description,
+			Tags:        descriptor.Tags,
+			Metadata:    descriptor.Metadata,
+		}
+		for _, dep := range c.dependenciesOf(descriptor) {
+			service.Dependencies = append(service.Dependencies, dep.String())
+		}
+		services = append(services, service)
+	}
+	c.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (c *Container) adminStats(w http.ResponseWriter, r *http.Request) {
+	stats := []adminStats{}
+	for _, s := range c.Stats().Services {
+		entry := adminStats{
+			Type:        s.ServiceType.String(),
+			Lifecycle:   s.Lifecycle.String(),
+			Created:     s.Created,
+			Failures:    s.Failures,
+			Degraded:    s.Degraded,
+			Waits:       s.Waits,
+			WaitTimeMs:  s.WaitTime.Milliseconds(),
+			ApproxBytes: s.ApproxBytes,
+		}
+		if s.LastError != nil {
+			entry.LastError = s.LastError.Error()
+		}
+		stats = append(stats, entry)
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (c *Container) adminGraph(w http.ResponseWriter, r *http.Request) {
+	c.mu.RLock()
+	edges := []adminEdge{}
+	for _, descriptor := range c.sortedDescriptors() {
+		for _, dep := range c.dependenciesOf(descriptor) {
+			edges = append(edges, adminEdge{From: descriptor.ServiceType.String(), To: dep.String()})
+		}
+	}
+	c.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, edges)
+}
+
+func (c *Container) adminHealth(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	results := []adminResult{}
+	for _, result := range c.CheckHealth(r.Context()) {
+		entry := adminResult{Type: result.ServiceType.String(), OK: result.Err == nil, Degraded: result.Degraded}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+			status = http.StatusServiceUnavailable
+		}
+		results = append(results, entry)
+	}
+	writeJSON(w, status, results)
+}
+
+func (c *Container) adminWarmup(w http.ResponseWriter, r *http.Request) {
+	var filters []ServiceFilter
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		filters = append(filters, ByTag(tag))
+	}
+
+	err := c.Warmup(r.Context(), filters...)
+	if err == nil {
+		writeJSON(w, http.StatusOK, adminResult{OK: true})
+		return
+	}
+
+	result := adminResult{}
+	for _, e := range flattenErrors(err) {
+		result.Errors = append(result.Errors, e.Error())
+	}
+	writeJSON(w, http.StatusInternalServerError, result)
+}
+
+func (c *Container) adminEvict(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("type")
+
+	c.mu.RLock()
+	descriptor, ok := c.descriptorByName(name)
+	c.mu.RUnlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, adminResult{Type: name, Error: "no service registered as " + name})
+		return
+	}
+
+	if err := c.evictSingleton(descriptor.ServiceType); err != nil {
+		status := http.StatusInternalServerError
+		if ErrorCodeOf(err) == ErrCodeInvalidArgument {
+			status = http.StatusBadRequest
+		}
+		writeJSON(w, status, adminResult{Type: name, Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, adminResult{Type: name, OK: true})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,99 @@
+package inject
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func adminRequest(t *testing.T, handler http.Handler, method, target string, v interface{}) int {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	if v != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s %s returned invalid JSON: %v", method, target, err)
+		}
+	}
+	return recorder.Code
+}
+
+func TestAdminHandler(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "admin"}
+	}, WithTags("critical"), WithMetadata("owner", "platform"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	handler := AdminHandler(container, func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer secret"
+	})
+
+	var services []adminService
+	if code := adminRequest(t, handler, http.MethodGet, "/services", &services); code != http.StatusOK {
+		t.Fatalf("Expected 200 from /services, got %d", code)
+	}
+	if len(services) != 2 || services[0].Metadata["owner"] != "platform" || services[1].Dependencies[0] != "inject.TestInterface" {
+		t.Errorf("Unexpected services: %+v", services)
+	}
+
+	var edges []adminEdge
+	adminRequest(t, handler, http.MethodGet, "/graph", &edges)
+	if len(edges) != 1 || edges[0].From != "inject.TestService" {
+		t.Errorf("Unexpected graph: %+v", edges)
+	}
+
+	var result adminResult
+	if code := adminRequest(t, handler, http.MethodPost, "/warmup?tag=critical", &result); code != http.StatusOK || !result.OK {
+		t.Errorf("Warmup failed: %d %+v", code, result)
+	}
+
+	var stats []adminStats
+	adminRequest(t, handler, http.MethodGet, "/stats", &stats)
+	if stats[0].Created != 1 {
+		t.Errorf("Warmup should have built the tagged singleton: %+v", stats)
+	}
+
+	if code := adminRequest(t, handler, http.MethodPost, "/evict?type=inject.TestInterface", &result); code != http.StatusOK {
+		t.Errorf("Expected 200 from /evict, got %d: %+v", code, result)
+	}
+	if code := adminRequest(t, handler, http.MethodPost, "/evict?type=inject.TestService", &result); code != http.StatusBadRequest {
+		t.Errorf("Evicting a transient should be rejected, got %d", code)
+	}
+	if code := adminRequest(t, handler, http.MethodPost, "/evict?type=inject.Missing", &result); code != http.StatusNotFound {
+		t.Errorf("Evicting an unknown type should return 404, got %d", code)
+	}
+
+	var health []adminResult
+	if code := adminRequest(t, handler, http.MethodGet, "/health", &health); code != http.StatusOK {
+		t.Errorf("Expected 200 from /health, got %d", code)
+	}
+}
+
+func TestAdminHandlerRequiresAuthorization(t *testing.T) {
+	container := NewContainer()
+
+	for _, handler := range []http.Handler{
+		AdminHandler(container, nil),
+		AdminHandler(container, func(r *http.Request) bool { return false }),
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/services", nil))
+		if recorder.Code != http.StatusUnauthorized || !strings.Contains(recorder.Body.String(), "unauthorized") {
+			t.Errorf("Expected 401, got %d", recorder.Code)
+		}
+	}
+}
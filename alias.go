@@ -0,0 +1,67 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// As also registers the service under T, typically an interface the
+// instance implements, so one registration serves several types:
+//
+//	c.RegisterFunc(NewFileLogger, inject.Singleton, inject.As[Logger](), inject.As[Flusher]())
+//
+// Resolving T resolves the service itself, so a singleton is the same
+// instance under every type.
+func As[T any]() RegistrationOption {
+	alias := reflect.TypeFor[T]()
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.aliases = append(descriptor.aliases, alias)
+	}
+}
+
+func checkAliases(descriptor *ServiceDescriptor, returnType reflect.Type) error {
+	if len(descriptor.aliases) > 0 && (descriptor.Lifecycle == Pooled || descriptor.Lifecycle == Keyed) {
+		return newError(ErrCodeInvalidArgument, descriptor.ServiceType, "%s service %s cannot be registered under other types", strings.ToLower(descriptor.Lifecycle.String()), descriptor.ServiceType.String())
+	}
+	for _, alias := range descriptor.aliases {
+		if alias == descriptor.ServiceType {
+			return newError(ErrCodeInvalidArgument, alias, "service %s cannot be an alias of itself", alias.String())
+		}
+		if err := checkReturnType(alias, returnType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newAliasDescriptor registers alias, under the same name, as a transient
+// view of target. Its factory takes target's type, so dependency checks and
+// graphs see the edge.
+func newAliasDescriptor(alias reflect.Type, target serviceKey, appended bool) *ServiceDescriptor {
+	factoryType := reflect.FuncOf([]reflect.Type{target.serviceType}, []reflect.Type{alias}, false)
+	factory := reflect.MakeFunc(factoryType, func(args []reflect.Value) []reflect.Value {
+		result := reflect.New(alias).Elem()
+		result.Set(args[0])
+		return []reflect.Value{result}
+	})
+	return &ServiceDescriptor{
+		ServiceType: alias,
+		Name:        target.name,
+		Factory:     factory.Interface(),
+		Lifecycle:   Transient,
+		Description: fmt.Sprintf("alias of %s", target.serviceType.String()),
+		appended:    appended,
+		create: func(c *Container) (interface{}, error) {
+			descriptor, exists := c.services[target]
+			if !exists {
+				return nil, c.notRegisteredError(target.serviceType)
+			}
+			instance, err := c.resolveDescriptor(descriptor)
+			if err != nil {
+				return nil, dependencyError(target.serviceType, err)
+			}
+			return instance, nil
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package inject
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+type TestFlusher interface {
+	Flush() error
+}
+
+type TestLogger struct {
+	value string
+}
+
+func (l *TestLogger) GetValue() string {
+	return l.value
+}
+
+func (l *TestLogger) Flush() error {
+	return nil
+}
+
+func TestRegisterFuncAs(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterFunc(func() *TestLogger {
+		return &TestLogger{value: "logger"}
+	}, Singleton, As[TestInterface](), As[TestFlusher]())
+	if err != nil {
+		t.Fatalf("Failed to register func: %v", err)
+	}
+
+	logger, err := container.Resolve((**TestLogger)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve concrete type: %v", err)
+	}
+	value := MustResolve[TestInterface](container)
+	flusher := MustResolve[TestFlusher](container)
+	if value != logger || flusher != logger {
+		t.Error("Expected every alias to resolve the same singleton")
+	}
+	if value.GetValue() != "logger" {
+		t.Error("Alias should resolve the registered instance")
+	}
+}
+
+type closingLogger struct {
+	TestLogger
+	closes int
+}
+
+func (l *closingLogger) Close() error {
+	l.closes++
+	return nil
+}
+
+func TestAsKeepsSingletonIdentity(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*closingLogger](container, func(c *Container) *closingLogger {
+		return &closingLogger{}
+	}, As[TestInterface](), As[TestFlusher](), As[io.Closer]())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	logger := MustResolve[*closingLogger](container)
+	if MustResolve[TestInterface](container) != logger || MustResolve[TestFlusher](container) != logger || MustResolve[io.Closer](container) != logger {
+		t.Error("Expected every interface to resolve the same singleton")
+	}
+
+	if err := container.Close(); err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if logger.closes != 1 {
+		t.Errorf("Expected the singleton to be closed once, got %d", logger.closes)
+	}
+}
+
+func TestAsFollowsLifecycle(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestLogger)(nil), func() *TestLogger {
+		return &TestLogger{}
+	}, As[TestFlusher]())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if MustResolve[TestFlusher](container) == MustResolve[TestFlusher](container) {
+		t.Error("Expected a transient service to be created per resolution under its alias")
+	}
+}
+
+func TestAsValidation(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterFunc(func() *TestRepository {
+		return &TestRepository{}
+	}, Singleton, As[TestFlusher]())
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH for an unimplemented interface, got %v", err)
+	}
+	if container.Has((*TestRepository)(nil)) {
+		t.Error("A rejected registration should not be installed")
+	}
+
+	err = container.Register((*TestLogger)(nil), func() *TestLogger {
+		return &TestLogger{}
+	}, Pooled, As[TestFlusher]())
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a pooled alias, got %v", err)
+	}
+}
+
+func TestAsReportsMissingTarget(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestLogger)(nil), func(dep TestInterface) *TestLogger {
+		return &TestLogger{}
+	}, As[TestFlusher]())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestFlusher)(nil))
+	if ErrorCodeOf(err) != ErrCodeNotRegistered || !strings.Contains(err.Error(), "inject.TestInterface") {
+		t.Errorf("Expected the root cause to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const DefaultAppTimeout = 15 * time.Second
+
+// Hook is a pair of functions run when an App starts and stops. Either may
+// be nil. Name labels the hook in errors and traces.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Hooks collects the hooks of an App. NewApp registers it as a singleton, so
+// factories take a *Hooks parameter and append to it. Since a service is
+// built after its dependencies, hooks are appended in dependency order.
+type Hooks struct {
+	mu    sync.Mutex
+	hooks []Hook
+}
+
+func (h *Hooks) Append(hook Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *Hooks) snapshot() []Hook {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Hook(nil), h.hooks...)
+}
+
+// App runs the services of a container as an application: it builds the
+// singletons, runs their OnStart hooks in dependency order, and on shutdown
+// runs the OnStop hooks in reverse before closing the container.
+type App struct {
+	container    *Container
+	hooks        *Hooks
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+
+	mu      sync.Mutex
+	started []Hook
+}
+
+type AppOption func(*App)
+
+func WithStartTimeout(timeout time.Duration) AppOption {
+	return func(a *App) {
+		a.startTimeout = timeout
+	}
+}
+
+func WithStopTimeout(timeout time.Duration) AppOption {
+	return func(a *App) {
+		a.stopTimeout = timeout
+	}
+}
+
+// NewApp registers *Hooks with the container and returns an App for it.
+func NewApp(container *Container, opts ...AppOption) (*App, error) {
+	app := &App{
+		container:    container,
+		hooks:        &Hooks{},
+		startTimeout: DefaultAppTimeout,
+		stopTimeout:  DefaultAppTimeout,
+	}
+	for _, opt := range opts {
+		opt(app)
+	}
+	if err := RegisterValue[*Hooks](container, app.hooks); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// Start builds every singleton, which appends their hooks, then runs the
+// OnStart hooks in the order they were appended. If one fails, the hooks
+// already started are stopped in reverse and the failure is returned.
+func (a *App) Start(ctx context.Context) error {
+	if err := a.container.Warmup(ctx); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, hook := range a.hooks.snapshot() {
+		if hook.Name == "" {
+			hook.Name = fmt.Sprintf("hook %d", i+1)
+		}
+		if hook.OnStart != nil {
+			if err := a.run(ctx, "OnStart", hook.Name, hook.OnStart); err != nil {
+				return errors.Join(err, a.stop(ctx))
+			}
+		}
+		a.started = append(a.started, hook)
+	}
+	return nil
+}
+
+// Stop runs the OnStop hooks of the started hooks in reverse order, then
+// closes the container. Every failure is returned.
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	err := a.stop(ctx)
+	a.mu.Unlock()
+
+	return errors.Join(err, a.container.Close())
+}
+
+// stop runs the OnStop hooks of the started hooks. Callers must hold a.mu.
+func (a *App) stop(ctx context.Context) error {
+	var errs []error
+	for i := len(a.started) - 1; i >= 0; i-- {
+		hook := a.started[i]
+		if hook.OnStop != nil {
+			if err := a.run(ctx, "OnStop", hook.Name, hook.OnStop); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	a.started = nil
+	return errors.Join(errs...)
+}
+
+func (a *App) run(ctx context.Context, phase, name string, fn func(context.Context) error) error {
+	start := time.Now()
+	err := fn(ctx)
+	if a.container.tracer != nil {
+		a.container.tracer.record(name, phase, 0, start, err)
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", phase, name, err)
+	}
+	return nil
+}
+
+// Run starts the app, blocks until SIGINT or SIGTERM, then stops it.
+func (a *App) Run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	return a.RunContext(ctx)
+}
+
+// RunContext starts the app, blocks until ctx ends, then stops it. The start
+// and stop timeouts bound the hooks of each phase.
+func (a *App) RunContext(ctx context.Context) error {
+	startCtx, cancel := context.WithTimeout(ctx, a.startTimeout)
+	err := a.Start(startCtx)
+	cancel()
+	if err != nil {
+		return errors.Join(err, a.container.Close())
+	}
+
+	<-ctx.Done()
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), a.stopTimeout)
+	defer cancel()
+	return a.Stop(stopCtx)
+}
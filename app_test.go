@@ -0,0 +1,118 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type appDatabase struct{}
+
+type appServer struct {
+	db *appDatabase
+}
+
+func registerAppServices(t *testing.T, container *Container, events *[]string, startErr error) {
+	t.Helper()
+
+	err := container.RegisterSingleton((**appDatabase)(nil), func(hooks *Hooks) *appDatabase {
+		hooks.Append(Hook{
+			Name: "database",
+			OnStart: func(ctx context.Context) error {
+				*events = append(*events, "start database")
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				*events = append(*events, "stop database")
+				return nil
+			},
+		})
+		return &appDatabase{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register database: %v", err)
+	}
+	err = container.RegisterSingleton((**appServer)(nil), func(hooks *Hooks, db *appDatabase) *appServer {
+		hooks.Append(Hook{
+			Name: "server",
+			OnStart: func(ctx context.Context) error {
+				*events = append(*events, "start server")
+				return startErr
+			},
+			OnStop: func(ctx context.Context) error {
+				*events = append(*events, "stop server")
+				return nil
+			},
+		})
+		return &appServer{db: db}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register server: %v", err)
+	}
+}
+
+func TestAppRunsHooksInDependencyOrder(t *testing.T) {
+	container := NewContainer()
+	app, err := NewApp(container)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	var events []string
+	registerAppServices(t, container, &events, nil)
+
+	// The app runs until its context ends; end it as soon as it has started
+	ctx, cancel := context.WithCancel(context.Background())
+	MustResolve[*Hooks](container).Append(Hook{
+		OnStart: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+	if err := app.RunContext(ctx); err != nil {
+		t.Fatalf("Failed to run app: %v", err)
+	}
+
+	expected := []string{"start database", "start server", "stop server", "stop database"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, events)
+			break
+		}
+	}
+
+	if _, err := container.Resolve((**appServer)(nil)); ErrorCodeOf(err) != ErrCodeContainerClosed {
+		t.Errorf("Expected the container to be closed after the app stops, got %v", err)
+	}
+}
+
+func TestAppStartFailureStopsStartedHooks(t *testing.T) {
+	container := NewContainer()
+	app, err := NewApp(container)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	var events []string
+	errListen := errors.New("address in use")
+	registerAppServices(t, container, &events, errListen)
+
+	err = app.Start(context.Background())
+	if !errors.Is(err, errListen) {
+		t.Fatalf("Expected the start failure, got %v", err)
+	}
+
+	expected := []string{"start database", "start server", "stop database"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, events)
+			break
+		}
+	}
+}
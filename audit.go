@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditRegister AuditAction = "register"
+	AuditClear    AuditAction = "clear"
+	AuditEvict    AuditAction = "evict"
+	AuditReset    AuditAction = "reset singletons"
+)
+
+type AuditRecord struct {
+	Time        time.Time
+	Action      AuditAction
+	ServiceType reflect.Type
+	Lifecycle   Lifecycle
+	Replaced    bool
+	Caller      string
+	Function    string
+}
+
+func (r AuditRecord) String() string {
+	subject := "all services"
+	if r.ServiceType != nil {
+		subject = fmt.Sprintf("%s (%s)", r.ServiceType.String(), r.Lifecycle)
+	}
+	action := string(r.Action)
+	if r.Replaced {
+		action += " (replaced)"
+	}
+	return fmt.Sprintf("%s %s %s at %s in %s", r.Time.Format(time.RFC3339), action, subject, r.Caller, r.Function)
+}
+
+func (c *Container) AuditLog() []AuditRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]AuditRecord(nil), c.audit...)
+}
+
+// recordAudit appends to the audit log. Callers must hold c.mu.
+func (c *Container) recordAudit(record AuditRecord) {
+	record.Time = time.Now()
+	record.Caller, record.Function = externalCaller()
+	c.audit = append(c.audit, record)
+}
+
+var packagePath = reflect.TypeOf(Container{}).PkgPath()
+
+// externalCaller reports the first stack frame outside this package, so the
+// audit log points at the wiring code rather than at helper functions. On a
+// goroutine started by this package there is none, and it reports
+// unknownCaller.
+func externalCaller() (string, string) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.goexit" {
+			return unknownCaller, unknownCaller
+		}
+		internal := strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line), frame.Function
+		}
+		if !more {
+			return unknownCaller, unknownCaller
+		}
+	}
+}
+
+const unknownCaller = "unknown"
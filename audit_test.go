@@ -0,0 +1,75 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to re-register service: %v", err)
+	}
+
+	err = container.Register((*TestImplementation)(nil), "not a function", Transient)
+	if err == nil {
+		t.Fatal("Expected error for invalid factory")
+	}
+
+	container.Clear()
+
+	records := container.AuditLog()
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 audit records, got %d", len(records))
+	}
+
+	first, second, third := records[0], records[1], records[2]
+	if first.Action != AuditRegister || first.Replaced || first.Lifecycle != Singleton {
+		t.Errorf("Unexpected first record: %+v", first)
+	}
+	if second.Action != AuditRegister || !second.Replaced || second.Lifecycle != Transient {
+		t.Errorf("Second registration should be recorded as a replacement: %+v", second)
+	}
+	if third.Action != AuditClear || third.ServiceType != nil {
+		t.Errorf("Clear should be recorded: %+v", third)
+	}
+
+	for _, record := range records {
+		if !strings.Contains(record.Caller, "audit_test.go") {
+			t.Errorf("Caller should point at the wiring code, got '%s'", record.Caller)
+		}
+		if !strings.HasSuffix(record.Function, "TestAuditLog") {
+			t.Errorf("Function should name the wiring code, got '%s'", record.Function)
+		}
+		if record.Time.IsZero() {
+			t.Error("Audit records should be timestamped")
+		}
+	}
+
+	if !strings.Contains(second.String(), "register (replaced) *inject.TestImplementation (Transient)") {
+		t.Errorf("Unexpected record string: %s", second.String())
+	}
+}
+
+func TestAuditLogIsACopy(t *testing.T) {
+	container := NewContainer()
+	container.Clear()
+
+	records := container.AuditLog()
+	records[0].Action = AuditRegister
+
+	if container.AuditLog()[0].Action != AuditClear {
+		t.Error("AuditLog should return a copy of the records")
+	}
+}
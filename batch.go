@@ -0,0 +1,69 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ResolveMany resolves every type under a single read lock, so no
+// registration can change part way through the batch. Every failure is
+// reported in the joined error, and on failure no services are returned.
+func (c *Container) ResolveMany(types ...reflect.Type) (map[reflect.Type]interface{}, error) {
+	var errs []error
+	for _, serviceType := range types {
+		if serviceType == nil {
+			return nil, newError(ErrCodeInvalidArgument, nil, "service type must not be nil")
+		}
+		if err := c.checkPolicy(serviceType); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	defer c.readLock()()
+
+	services := make(map[reflect.Type]interface{}, len(types))
+	for _, serviceType := range types {
+		if _, done := services[serviceType]; done {
+			continue
+		}
+		service, err := c.resolveType(serviceType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		services[serviceType] = service
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return services, nil
+}
+
+// Resolve2 resolves two services at once, reporting both failures together.
+func Resolve2[A, B any](container Resolver) (A, B, error) {
+	a, errA := resolveAs[A](container)
+	b, errB := resolveAs[B](container)
+	if err := errors.Join(errA, errB); err != nil {
+		var zeroA A
+		var zeroB B
+		return zeroA, zeroB, err
+	}
+	return a, b, nil
+}
+
+// Resolve3 resolves three services at once, reporting all failures together.
+func Resolve3[A, B, C any](container Resolver) (A, B, C, error) {
+	a, errA := resolveAs[A](container)
+	b, errB := resolveAs[B](container)
+	c, errC := resolveAs[C](container)
+	if err := errors.Join(errA, errB, errC); err != nil {
+		var zeroA A
+		var zeroB B
+		var zeroC C
+		return zeroA, zeroB, zeroC, err
+	}
+	return a, b, c, nil
+}
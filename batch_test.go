@@ -0,0 +1,93 @@
+package inject
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveMany(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "many"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	interfaceType := reflect.TypeFor[TestInterface]()
+	serviceType := reflect.TypeFor[TestService]()
+	services, err := container.ResolveMany(interfaceType, serviceType, interfaceType)
+	if err != nil {
+		t.Fatalf("Failed to resolve services: %v", err)
+	}
+	if len(services) != 2 {
+		t.Fatalf("Expected 2 services, got %d", len(services))
+	}
+	if services[serviceType].(*TestService).GetDependency() != services[interfaceType] {
+		t.Error("Expected the batch to share the singleton dependency")
+	}
+}
+
+func TestResolveManyReportsAllFailures(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	services, err := container.ResolveMany(reflect.TypeFor[TestInterface](), reflect.TypeFor[TestService](), reflect.TypeFor[TestRepository]())
+	if err == nil {
+		t.Fatal("Expected an error for unregistered services")
+	}
+	if services != nil {
+		t.Error("Expected no services when the batch fails")
+	}
+	for _, name := range []string{"inject.TestService", "inject.TestRepository"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected the error to mention %s, got %v", name, err)
+		}
+	}
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+}
+
+func TestResolve2And3(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterValue[TestInterface](container, &TestImplementation{value: "tuple"})
+	if err != nil {
+		t.Fatalf("Failed to register value: %v", err)
+	}
+	err = RegisterValue(container, &TestRepository{data: map[string]string{}})
+	if err != nil {
+		t.Fatalf("Failed to register value: %v", err)
+	}
+
+	dep, repo, err := Resolve2[TestInterface, *TestRepository](container)
+	if err != nil {
+		t.Fatalf("Failed to resolve pair: %v", err)
+	}
+	if dep.GetValue() != "tuple" || repo == nil {
+		t.Error("Resolve2 should return both services")
+	}
+
+	_, _, _, err = Resolve3[TestInterface, *TestService, *TestImplementation](container)
+	if err == nil {
+		t.Fatal("Expected an error for unregistered services")
+	}
+	if !strings.Contains(err.Error(), "*inject.TestService") || !strings.Contains(err.Error(), "*inject.TestImplementation") {
+		t.Errorf("Expected both failures to be reported, got %v", err)
+	}
+}
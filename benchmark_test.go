@@ -0,0 +1,54 @@
+package inject
+
+import "testing"
+
+func BenchmarkResolveTransientValue(b *testing.B) {
+	container := NewContainer()
+	RegisterTransientType[point](container, func(c *Container) point {
+		return point{X: 1, Y: 2}
+	})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		container.Resolve((*point)(nil))
+	}
+}
+
+func BenchmarkMustResolveTransientValue(b *testing.B) {
+	container := NewContainer()
+	RegisterTransientType[point](container, func(c *Container) point {
+		return point{X: 1, Y: 2}
+	})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		MustResolve[point](container)
+	}
+}
+
+func BenchmarkMustResolveSingleton(b *testing.B) {
+	container := NewContainer()
+	RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "test"}
+	})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		MustResolve[*TestImplementation](container)
+	}
+}
+
+func BenchmarkResolveTransientWithDependency(b *testing.B) {
+	container := NewContainer()
+	container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "test"}
+	})
+	container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		container.Resolve((*TestService)(nil))
+	}
+}
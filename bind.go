@@ -0,0 +1,78 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Bind resolves the leading parameters of fn from the container now and
+// returns a function of type F that takes the remaining parameters:
+//
+//	notify, err := inject.Bind[func(string) error](c, func(m Mailer, l Logger, to string) error { ... })
+//
+// F's parameters must match the trailing parameters of fn and its results
+// must match fn's results.
+func Bind[F any](resolver Resolver, fn interface{}) (F, error) {
+	var zero F
+	boundType := reflect.TypeFor[F]()
+	fnValue := reflect.ValueOf(fn)
+	if boundType.Kind() != reflect.Func || !fnValue.IsValid() || fnValue.Kind() != reflect.Func {
+		return zero, newError(ErrCodeInvalidArgument, boundType, "Bind requires a function and a function type to bind it to")
+	}
+	if c, ok := resolver.(*Container); ok && c.noReflection {
+		return zero, newError(ErrCodeReflectionDisabled, boundType, "Bind is disabled on containers created with WithNoReflection")
+	}
+
+	fnType := fnValue.Type()
+	leading := fnType.NumIn() - boundType.NumIn()
+	if err := checkBinding(fnType, boundType, leading); err != nil {
+		return zero, err
+	}
+
+	deps := make([]reflect.Value, leading)
+	for i := 0; i < leading; i++ {
+		dep, err := resolveValue(resolver, fnType.In(i))
+		if err != nil {
+			return zero, dependencyError(fnType.In(i), err)
+		}
+		deps[i] = dep
+	}
+
+	bound := reflect.MakeFunc(boundType, func(args []reflect.Value) []reflect.Value {
+		all := append(append(make([]reflect.Value, 0, fnType.NumIn()), deps...), args...)
+		if fnType.IsVariadic() {
+			return fnValue.CallSlice(all)
+		}
+		return fnValue.Call(all)
+	})
+	return bound.Interface().(F), nil
+}
+
+func MustBind[F any](resolver Resolver, fn interface{}) F {
+	bound, err := Bind[F](resolver, fn)
+	if err != nil {
+		panic(fmt.Sprintf("failed to bind %T: %v", fn, err))
+	}
+	return bound
+}
+
+func checkBinding(fnType, boundType reflect.Type, leading int) error {
+	mismatch := func() error {
+		return newError(ErrCodeTypeMismatch, boundType, "cannot bind %s to %s", fnType.String(), boundType.String())
+	}
+
+	if leading < 0 || fnType.IsVariadic() != boundType.IsVariadic() || fnType.NumOut() != boundType.NumOut() {
+		return mismatch()
+	}
+	for i := 0; i < boundType.NumIn(); i++ {
+		if fnType.In(leading+i) != boundType.In(i) {
+			return mismatch()
+		}
+	}
+	for i := 0; i < boundType.NumOut(); i++ {
+		if fnType.Out(i) != boundType.Out(i) {
+			return mismatch()
+		}
+	}
+	return nil
+}
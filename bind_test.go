@@ -0,0 +1,65 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	container := NewContainer()
+
+	resolved := 0
+	err := container.RegisterTransient((*TestInterface)(nil), func() TestInterface {
+		resolved++
+		return &TestImplementation{value: "greeter"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	greet, err := Bind[func(string, int) string](container, func(dep TestInterface, name string, times int) string {
+		return fmt.Sprintf("%s:%s", dep.GetValue(), strings.Repeat(name, times))
+	})
+	if err != nil {
+		t.Fatalf("Failed to bind function: %v", err)
+	}
+
+	if result := greet("ab", 2); result != "greeter:abab" {
+		t.Errorf("Unexpected result %q", result)
+	}
+	greet("x", 1)
+	if resolved != 1 {
+		t.Errorf("Dependencies should be resolved once at bind time, resolved %d times", resolved)
+	}
+
+	join := MustBind[func(...string) string](container, func(dep TestInterface, parts ...string) string {
+		return dep.GetValue() + "/" + strings.Join(parts, "/")
+	})
+	if result := join("a", "b"); result != "greeter/a/b" {
+		t.Errorf("Unexpected variadic result %q", result)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	container := NewContainer()
+
+	_, err := Bind[func(string) error](container, func(dep TestInterface, name string) error {
+		return nil
+	})
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	_, err = Bind[func(int) error](container, func(dep TestInterface, name string) error {
+		return nil
+	})
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH, got %v", err)
+	}
+
+	_, err = Bind[string](container, "not a function")
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+}
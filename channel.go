@@ -0,0 +1,24 @@
+package inject
+
+import "reflect"
+
+// channelKey maps a directional channel type with no registration of its
+// own, such as <-chan Event, to a registered bidirectional chan Event, so
+// producers and consumers can share one channel service. Callers must hold
+// c.mu.
+func (c *Container) channelKey(serviceType reflect.Type) (reflect.Type, bool) {
+	if serviceType.Kind() != reflect.Chan || serviceType.ChanDir() == reflect.BothDir {
+		return nil, false
+	}
+	bidirectional := reflect.ChanOf(reflect.BothDir, serviceType.Elem())
+	_, exists := c.services[serviceKey{serviceType: bidirectional}]
+	return bidirectional, exists
+}
+
+func (c *Container) resolveChannel(serviceType, bidirectional reflect.Type) (interface{}, error) {
+	instance, err := c.resolveType(bidirectional)
+	if err != nil || instance == nil {
+		return instance, err
+	}
+	return reflect.ValueOf(instance).Convert(serviceType).Interface(), nil
+}
@@ -0,0 +1,64 @@
+package inject
+
+import (
+	"testing"
+)
+
+type DomainEvent struct {
+	Name string
+}
+
+type eventProducer struct {
+	out chan<- DomainEvent
+}
+
+type eventConsumer struct {
+	in <-chan DomainEvent
+}
+
+func TestChannelServices(t *testing.T) {
+	container := NewContainer(WithDependencyChecks())
+
+	err := container.RegisterSingleton((*chan DomainEvent)(nil), func() chan DomainEvent {
+		return make(chan DomainEvent, 1)
+	})
+	if err != nil {
+		t.Fatalf("Failed to register channel: %v", err)
+	}
+	err = container.RegisterSingleton((*eventProducer)(nil), func(out chan<- DomainEvent) *eventProducer {
+		return &eventProducer{out: out}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register producer: %v", err)
+	}
+	err = container.RegisterSingleton((*eventConsumer)(nil), func(in <-chan DomainEvent) *eventConsumer {
+		return &eventConsumer{in: in}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register consumer: %v", err)
+	}
+
+	producer, err := container.Resolve((*eventProducer)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve producer: %v", err)
+	}
+	consumer, err := container.Resolve((*eventConsumer)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve consumer: %v", err)
+	}
+
+	producer.(*eventProducer).out <- DomainEvent{Name: "order.created"}
+	if evt := <-consumer.(*eventConsumer).in; evt.Name != "order.created" {
+		t.Errorf("Consumer should receive from the producer's channel, got %v", evt)
+	}
+
+	if !container.Has((*<-chan DomainEvent)(nil)) {
+		t.Error("Directional views of a registered channel should be reported")
+	}
+	if _, ok := TryResolve[<-chan DomainEvent](container); !ok {
+		t.Error("Directional channels should resolve through the generic helpers")
+	}
+	if container.Has((*<-chan string)(nil)) {
+		t.Error("Unrelated channel types should not be reported")
+	}
+}
@@ -0,0 +1,46 @@
+package inject
+
+import (
+	"reflect"
+)
+
+var cleanupType = reflect.TypeFor[func()]()
+
+// returnsCleanup reports whether factoryType has the func(...) (T, func(),
+// error) shape, whose cleanup function releases what the factory acquired.
+// The container runs it after disposing the instance, when the container
+// or the scope owning the instance is closed.
+func returnsCleanup(factoryType reflect.Type) bool {
+	return factoryType.NumOut() == 3 &&
+		factoryType.Out(1) == cleanupType &&
+		factoryType.Out(2) == reflect.TypeFor[error]()
+}
+
+// keepCleanup hands cleanup to the flight building descriptor's instance,
+// whose owner records it next to the instance once construction completes.
+func (c *Container) keepCleanup(descriptor *ServiceDescriptor, cleanup func()) {
+	if cleanup == nil {
+		return
+	}
+
+	var f *flight
+	switch descriptor.Lifecycle {
+	case Singleton:
+		descriptor.mu.Lock()
+		defer descriptor.mu.Unlock()
+		f = descriptor.inflight
+	case Scoped:
+		c.scope.mu.Lock()
+		defer c.scope.mu.Unlock()
+		f = c.scope.instances[descriptor]
+	}
+	if f != nil {
+		f.cleanup = cleanup
+	}
+}
+
+func runCleanup(cleanup func()) {
+	if cleanup != nil {
+		cleanup()
+	}
+}
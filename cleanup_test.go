@@ -0,0 +1,83 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFactoryCleanup(t *testing.T) {
+	container := NewContainer()
+
+	var events []string
+	err := container.RegisterSingleton((**TestRepository)(nil), func() (*TestRepository, func(), error) {
+		return &TestRepository{}, func() { events = append(events, "repository") }, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = container.RegisterSingleton((**testClient)(nil), func(repo *TestRepository) (*testClient, func(), error) {
+		return &testClient{}, func() { events = append(events, "client") }, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register client: %v", err)
+	}
+
+	client := MustResolve[*testClient](container)
+	if err := container.Close(); err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if !client.closed {
+		t.Error("Close should still close instances that return a cleanup function")
+	}
+	if strings.Join(events, ",") != "client,repository" {
+		t.Errorf("Cleanup functions should run in reverse order, got %v", events)
+	}
+}
+
+func TestFactoryCleanupInScope(t *testing.T) {
+	container := NewContainer()
+
+	cleanups := 0
+	err := container.Register((**TestRepository)(nil), func() (*TestRepository, func(), error) {
+		return &TestRepository{}, func() { cleanups++ }, nil
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	scope := container.NewScope()
+	MustResolve[*TestRepository](scope)
+	MustResolve[*TestRepository](scope)
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if cleanups != 1 {
+		t.Errorf("Scope should run the cleanup once, ran it %d times", cleanups)
+	}
+}
+
+func TestFactoryCleanupErrors(t *testing.T) {
+	container := NewContainer()
+
+	cleanups := 0
+	err := container.RegisterSingleton((**TestRepository)(nil), func() (*TestRepository, func(), error) {
+		return nil, func() { cleanups++ }, errors.New("connection refused")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	if _, err := container.Resolve((**TestRepository)(nil)); ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Expected FACTORY_ERROR, got %v", err)
+	}
+	if cleanups != 0 {
+		t.Error("Cleanup should not be kept when the factory fails")
+	}
+
+	err = container.RegisterTransient((**testClient)(nil), func() (*testClient, func(), error) {
+		return &testClient{}, func() {}, nil
+	})
+	if ErrorCodeOf(err) != ErrCodeInvalidFactory {
+		t.Errorf("Expected INVALID_FACTORY for a transient with a cleanup function, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Shutdowner is implemented by services that need a context to shut down,
+// such as *http.Server. Close prefers Shutdown over io.Closer.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Close disposes every singleton and keyed instance the container has
+// built, open generic instantiations included, most recently created first,
+// after the idle instances of pooled services. Instances implementing
+// Shutdowner are shut down and those implementing io.Closer are closed;
+// every failure is returned. Cleanup functions returned by factories run
+// right after their instance is disposed, and pooled instances still leased
+// are disposed when they are released. Failures disposing pooled instances
+// discarded earlier are reported too. Once closed, the container refuses
+// to resolve singletons and other services. Closing it again does nothing.
+func (c *Container) Close() error {
+	type owned struct {
+		serviceType reflect.Type
+		instance    interface{}
+		cleanup     func()
+		built       uint64
+	}
+
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	c.mu.Lock()
+	var pooled, instances []owned
+	var errs []error
+	for _, descriptor := range append(c.sortedDescriptors(), c.genericInstances()...) {
+		switch descriptor.Lifecycle {
+		case Singleton:
+			if instance, cleanup, built := descriptor.dropInstance(); instance != nil {
+				instances = append(instances, owned{descriptor.ServiceType, instance, cleanup, built})
+			}
+		case Keyed:
+			descriptor.mu.Lock()
+			for _, f := range descriptor.keyedInstances {
+				if f.built != 0 {
+					instances = append(instances, owned{descriptor.ServiceType, f.instance, nil, f.built})
+				}
+			}
+			descriptor.keyedInstances = nil
+			descriptor.mu.Unlock()
+		case Pooled:
+			idle, failures := descriptor.pool.drain()
+			for _, instance := range idle {
+				pooled = append(pooled, owned{serviceType: descriptor.ServiceType, instance: instance})
+			}
+			for _, err := range failures {
+				errs = append(errs, fmt.Errorf("failed to close pooled %s: %w", descriptor.ServiceType.String(), err))
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].built > instances[j].built
+	})
+	// Pooled instances may have been built from singletons, never the
+	// other way round
+	instances = append(pooled, instances...)
+
+	for _, o := range instances {
+		if err := dispose(o.instance); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", o.serviceType.String(), err))
+		}
+		runCleanup(o.cleanup)
+	}
+	return errors.Join(errs...)
+}
+
+func dispose(instance interface{}) error {
+	switch v := instance.(type) {
+	case Shutdowner:
+		return v.Shutdown(context.Background())
+	case io.Closer:
+		return v.Close()
+	}
+	if instance != nil && isResultStruct(reflect.TypeOf(instance)) {
+		return disposeResultFields(reflect.ValueOf(instance))
+	}
+	return nil
+}
+
+func errContainerClosed(serviceType reflect.Type) error {
+	return newError(ErrCodeContainerClosed, serviceType, "container is closed")
+}
+
+// disposeLate disposes an instance whose construction finished after Close
+// had collected the instances to dispose, and returns the error its
+// resolution fails with.
+func disposeLate(serviceType reflect.Type, instance interface{}) error {
+	err := errContainerClosed(serviceType)
+	if disposeErr := dispose(instance); disposeErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close %s: %w", serviceType.String(), disposeErr))
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type shutdownRecorder struct {
+	closed *[]string
+}
+
+func (s *shutdownRecorder) Shutdown(ctx context.Context) error {
+	*s.closed = append(*s.closed, "shutdown")
+	return nil
+}
+
+func TestContainerClose(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	err := RegisterSingletonType[*orderedCloser](container, func(c *Container) *orderedCloser {
+		return &orderedCloser{name: "first", closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register first closer: %v", err)
+	}
+	err = RegisterSingletonType[*shutdownRecorder](container, func(c *Container) *shutdownRecorder {
+		MustResolve[*orderedCloser](c)
+		return &shutdownRecorder{closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register shutdown recorder: %v", err)
+	}
+	err = RegisterKeyed[*otherCloser](container, func(c *Container, key string) (*otherCloser, error) {
+		MustResolve[*shutdownRecorder](c)
+		return &otherCloser{orderedCloser{name: key, closed: &closed}}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register keyed closer: %v", err)
+	}
+	err = RegisterSingletonType[*failingCloser](container, func(c *Container) *failingCloser {
+		return &failingCloser{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register failing closer: %v", err)
+	}
+
+	if _, err := ResolveKeyed[*otherCloser](container, "keyed"); err != nil {
+		t.Fatalf("Failed to resolve keyed closer: %v", err)
+	}
+	MustResolve[*failingCloser](container)
+
+	if err := container.Close(); !errors.Is(err, errCloseFailed) {
+		t.Errorf("Expected the close failure to be returned, got %v", err)
+	}
+	if len(closed) != 3 || closed[0] != "keyed" || closed[1] != "shutdown" || closed[2] != "first" {
+		t.Errorf("Expected instances disposed in reverse creation order, got %v", closed)
+	}
+
+	if _, err := container.Resolve((**orderedCloser)(nil)); ErrorCodeOf(err) != ErrCodeContainerClosed {
+		t.Errorf("Expected resolution from a closed container to fail, got %v", err)
+	}
+	if err := container.Close(); err != nil {
+		t.Errorf("Expected a second Close to do nothing, got %v", err)
+	}
+	if len(closed) != 3 {
+		t.Errorf("Expected instances to be disposed once, got %v", closed)
+	}
+}
+
+func TestCloseDisposesSingletonFinishedLater(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	started, release := make(chan struct{}), make(chan struct{})
+	err := container.RegisterSingleton((**shutdownRecorder)(nil), func() *shutdownRecorder {
+		close(started)
+		<-release
+		return &shutdownRecorder{closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := container.Resolve((**shutdownRecorder)(nil))
+		done <- err
+	}()
+	<-started
+	closeDone := make(chan error)
+	go func() {
+		closeDone <- container.Close()
+	}()
+	// Close waits for the resolution holding the read lock; let the
+	// factory return once Close has been requested
+	for !container.closed.Load() {
+		runtime.Gosched()
+	}
+	close(release)
+
+	if err := <-done; ErrorCodeOf(err) != ErrCodeContainerClosed {
+		t.Errorf("Expected CONTAINER_CLOSED for a singleton finished after Close, got %v", err)
+	}
+	if err := <-closeDone; err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if strings.Join(closed, ",") != "shutdown" {
+		t.Errorf("Expected the late singleton to be shut down once, got %v", closed)
+	}
+}
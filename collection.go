@@ -0,0 +1,79 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// collectionKey maps a []T or map[string]T that is not registered itself to
+// T, when T has registrations to collect: a slice receives every
+// registration of T outside groups, like ResolveAll, and a map receives the
+// named ones by name. Callers must hold c.mu.
+func (c *Container) collectionKey(serviceType reflect.Type) (reflect.Type, bool) {
+	var match func(serviceKey) bool
+	switch {
+	case serviceType.Kind() == reflect.Slice:
+		match = ungrouped
+	case serviceType.Kind() == reflect.Map && serviceType.Key().Kind() == reflect.String:
+		match = namedUngrouped
+	default:
+		return nil, false
+	}
+
+	elem := serviceType.Elem()
+	for key := range c.services {
+		if key.serviceType == elem && match(key) {
+			return elem, true
+		}
+	}
+	return nil, false
+}
+
+func ungrouped(key serviceKey) bool {
+	return key.group == ""
+}
+
+func namedUngrouped(key serviceKey) bool {
+	return key.name != "" && key.group == ""
+}
+
+// resolveCollection resolves the registrations of elem into a new
+// collection of serviceType. Callers must hold c.mu.
+func (c *Container) resolveCollection(serviceType, elem reflect.Type) (interface{}, error) {
+	if serviceType.Kind() == reflect.Slice {
+		instances, err := c.resolveMatching(elem, ungrouped)
+		if err != nil {
+			return nil, err
+		}
+		collection := reflect.MakeSlice(serviceType, 0, len(instances))
+		for _, instance := range instances {
+			collection = reflect.Append(collection, valueOrZero(elem, instance))
+		}
+		return collection.Interface(), nil
+	}
+
+	descriptors := c.matching(elem, namedUngrouped)
+	collection := reflect.MakeMapWithSize(serviceType, len(descriptors))
+	var errs []error
+	for _, descriptor := range descriptors {
+		instance, err := c.resolveDescriptor(descriptor)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve %s: %w", descriptor.displayName(), err))
+			continue
+		}
+		key := reflect.ValueOf(descriptor.Name).Convert(serviceType.Key())
+		collection.SetMapIndex(key, valueOrZero(elem, instance))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return collection.Interface(), nil
+}
+
+func valueOrZero(t reflect.Type, instance interface{}) reflect.Value {
+	if instance == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(instance)
+}
@@ -0,0 +1,107 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type middlewareChain struct {
+	middleware []TestInterface
+}
+
+type migrationSet struct {
+	steps map[string]TestInterface
+}
+
+func TestInjectCollections(t *testing.T) {
+	container := NewContainer()
+
+	for _, name := range []string{"auth", "logging"} {
+		err := RegisterValueAs[TestInterface](container, &TestImplementation{value: name}, WithName(name))
+		if err != nil {
+			t.Fatalf("Failed to register %s: %v", name, err)
+		}
+	}
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "default"})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterGroup[TestInterface](container, "routes", func(c *Container) TestInterface {
+		return &TestImplementation{value: "route"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register group member: %v", err)
+	}
+
+	err = container.RegisterTransient((**middlewareChain)(nil), func(middleware []TestInterface) *middlewareChain {
+		return &middlewareChain{middleware: middleware}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register chain: %v", err)
+	}
+	err = container.RegisterTransient((**migrationSet)(nil), func(steps map[string]TestInterface) *migrationSet {
+		return &migrationSet{steps: steps}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register migrations: %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Collections should satisfy Validate: %v", err)
+	}
+
+	var values []string
+	for _, m := range MustResolve[*middlewareChain](container).middleware {
+		values = append(values, m.GetValue())
+	}
+	if strings.Join(values, ",") != "auth,logging,default" {
+		t.Errorf("A slice should collect every registration outside groups in order, got %v", values)
+	}
+
+	steps := MustResolve[*migrationSet](container).steps
+	if len(steps) != 2 || steps["auth"].GetValue() != "auth" || steps["logging"].GetValue() != "logging" {
+		t.Errorf("A map should collect the named registrations by name, got %v", steps)
+	}
+}
+
+func TestInjectCollectionPrefersRegistration(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "collected"})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterValue[[]TestInterface](container, []TestInterface{&TestImplementation{value: "registered"}})
+	if err != nil {
+		t.Fatalf("Failed to register slice: %v", err)
+	}
+
+	if got := MustResolve[[]TestInterface](container); len(got) != 1 || got[0].GetValue() != "registered" {
+		t.Errorf("A registered slice should take precedence over collecting, got %v", got)
+	}
+	if _, err := container.Resolve((*[]*TestRepository)(nil)); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED when nothing can be collected, got %v", err)
+	}
+}
+
+func TestInjectCollectionPolicy(t *testing.T) {
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		if request.ServiceType == reflect.TypeFor[TestInterface]() {
+			return errors.New("secrets must not be collected")
+		}
+		return nil
+	}))
+
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "secret"}, WithName("api"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*[]TestInterface)(nil)); ErrorCodeOf(err) != ErrCodeAccessDenied {
+		t.Errorf("Expected ACCESS_DENIED for a slice, got %v", err)
+	}
+	if _, err := container.Resolve((*map[string]TestInterface)(nil)); ErrorCodeOf(err) != ErrCodeAccessDenied {
+		t.Errorf("Expected ACCESS_DENIED for a map, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package inject
+
+type CompositeResolver struct {
+	containers []*Container
+}
+
+func NewCompositeResolver(primary *Container, fallbacks ...*Container) *CompositeResolver {
+	containers := make([]*Container, 0, len(fallbacks)+1)
+	containers = append(containers, primary)
+	containers = append(containers, fallbacks...)
+	return &CompositeResolver{containers: containers}
+}
+
+func (r *CompositeResolver) Resolve(serviceType interface{}) (interface{}, error) {
+	// Only fall through on missing registrations; factory errors from the
+	// container that owns the service are returned as-is.
+	for _, container := range r.containers {
+		if container.Has(serviceType) {
+			return container.Resolve(serviceType)
+		}
+	}
+	sType := serviceTypeOf(serviceType)
+	return nil, newError(ErrCodeNotRegistered, sType, "service of type %s not registered", sType.String())
+}
+
+func (r *CompositeResolver) Has(serviceType interface{}) bool {
+	for _, container := range r.containers {
+		if container.Has(serviceType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompositeResolverPrefersPrimary(t *testing.T) {
+	plugin := NewContainer()
+	host := NewContainer()
+
+	err := plugin.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "plugin"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register plugin service: %v", err)
+	}
+
+	err = host.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "host"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register host service: %v", err)
+	}
+
+	resolver := NewCompositeResolver(plugin, host)
+
+	service, err := resolver.Resolve((*TestInterface)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service.(TestInterface).GetValue() != "plugin" {
+		t.Error("Composite resolver should prefer the primary container")
+	}
+}
+
+func TestCompositeResolverFallsBack(t *testing.T) {
+	plugin := NewContainer()
+	host := NewContainer()
+
+	err := host.RegisterSingleton((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{value: "host"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register host service: %v", err)
+	}
+
+	resolver := NewCompositeResolver(plugin, host)
+
+	if !resolver.Has((*TestImplementation)(nil)) {
+		t.Error("Composite resolver should report services from fallback containers")
+	}
+
+	service, err := resolver.Resolve((*TestImplementation)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service.(*TestImplementation).GetValue() != "host" {
+		t.Error("Composite resolver should fall back to the secondary container")
+	}
+}
+
+func TestCompositeResolverDoesNotMaskFactoryErrors(t *testing.T) {
+	plugin := NewContainer()
+	host := NewContainer()
+
+	err := plugin.RegisterTransient((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		return nil, errors.New("plugin failure")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register plugin service: %v", err)
+	}
+
+	err = host.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{value: "host"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register host service: %v", err)
+	}
+
+	_, err = NewCompositeResolver(plugin, host).Resolve((*TestImplementation)(nil))
+	if err == nil || err.Error() != "plugin failure" {
+		t.Errorf("Expected plugin factory error, got %v", err)
+	}
+}
+
+func TestCompositeResolverUnregistered(t *testing.T) {
+	resolver := NewCompositeResolver(NewContainer(), NewContainer())
+
+	if resolver.Has((*TestImplementation)(nil)) {
+		t.Error("Composite resolver should not report unregistered services")
+	}
+
+	_, err := resolver.Resolve((*TestImplementation)(nil))
+	if err == nil {
+		t.Error("Expected error when resolving unregistered service")
+	}
+}
@@ -0,0 +1,129 @@
+package inject
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ConfigWatcher keeps a configuration value of type T registered in a
+// container and reloads it on demand. When the loaded value changes, every
+// singleton that depends on the configuration is rebuilt and the change
+// subscribers are notified.
+type ConfigWatcher[T any] struct {
+	container   *Container
+	load        func() (T, error)
+	mu          sync.Mutex
+	current     T
+	subscribers []func(old, new T)
+}
+
+// WatchConfig loads the initial configuration and registers it as a
+// singleton of type T.
+func WatchConfig[T any](c *Container, load func() (T, error), opts ...RegistrationOption) (*ConfigWatcher[T], error) {
+	value, err := load()
+	if err != nil {
+		return nil, factoryError(reflect.TypeFor[T](), err)
+	}
+
+	w := &ConfigWatcher[T]{container: c, load: load, current: value}
+	if err := RegisterSingletonType[T](c, func(*Container) T {
+		return w.Current()
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *ConfigWatcher[T]) Current() T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// OnChange subscribes fn to configuration changes. It is called after the
+// dependent singletons have been rebuilt.
+func (w *ConfigWatcher[T]) OnChange(fn func(old, new T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload loads the configuration again. Nothing happens when the value did
+// not change.
+func (w *ConfigWatcher[T]) Reload() error {
+	serviceType := reflect.TypeFor[T]()
+	value, err := w.load()
+	if err != nil {
+		return factoryError(serviceType, err)
+	}
+
+	w.mu.Lock()
+	old := w.current
+	if reflect.DeepEqual(old, value) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = value
+	subscribers := slices.Clone(w.subscribers)
+	w.mu.Unlock()
+
+	err = w.container.refresh(serviceType, true)
+	for _, fn := range subscribers {
+		fn(old, value)
+	}
+	return err
+}
+
+// PollFile reloads the configuration whenever the modification time or size
+// of path changes, checking every interval until ctx ends. Reload failures
+// go to the container's error reporter.
+func (w *ConfigWatcher[T]) PollFile(ctx context.Context, path string, interval time.Duration) {
+	last, _ := os.Stat(path)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err != nil || (last != nil && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size()) {
+				continue
+			}
+			last = info
+			w.reloadAndReport()
+		}
+	}()
+}
+
+// ReloadOnSignal reloads the configuration whenever one of signals, such as
+// syscall.SIGHUP, is received, until ctx ends.
+func (w *ConfigWatcher[T]) ReloadOnSignal(ctx context.Context, signals ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				w.reloadAndReport()
+			}
+		}
+	}()
+}
+
+func (w *ConfigWatcher[T]) reloadAndReport() {
+	if err := w.Reload(); err != nil {
+		w.container.report(reflect.TypeFor[T](), err, false)
+	}
+}
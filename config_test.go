@@ -0,0 +1,122 @@
+package inject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	DSN string
+}
+
+type testClient struct {
+	dsn    string
+	closed bool
+}
+
+func (c *testClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+type testRepository struct {
+	client *testClient
+}
+
+func TestConfigWatcherRefreshesDependents(t *testing.T) {
+	container := NewContainer()
+
+	dsn := "postgres://primary"
+	watcher, err := WatchConfig[testConfig](container, func() (testConfig, error) {
+		return testConfig{DSN: dsn}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to watch config: %v", err)
+	}
+
+	err = RegisterSingletonType[*testClient](container, func(c *Container) *testClient {
+		return &testClient{dsn: MustResolve[testConfig](c).DSN}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register client: %v", err)
+	}
+	err = container.RegisterSingleton((*testRepository)(nil), func(client *testClient) *testRepository {
+		return &testRepository{client: client}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	var changes []string
+	watcher.OnChange(func(old, new testConfig) {
+		changes = append(changes, old.DSN+" -> "+new.DSN)
+	})
+
+	repository, _ := container.Resolve((*testRepository)(nil))
+	oldClient := repository.(*testRepository).client
+
+	if err := watcher.Reload(); err != nil || len(changes) != 0 {
+		t.Fatalf("Reloading an unchanged config should be a no-op: %v", err)
+	}
+
+	dsn = "postgres://replica"
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("Failed to reload config: %v", err)
+	}
+
+	if !oldClient.closed {
+		t.Error("Client built from the old config should be closed")
+	}
+	repository, _ = container.Resolve((*testRepository)(nil))
+	if repository.(*testRepository).client.dsn != "postgres://replica" {
+		t.Error("Dependent singletons should be rebuilt with the new config")
+	}
+	if strings.Join(changes, ",") != "postgres://primary -> postgres://replica" {
+		t.Errorf("Unexpected change notifications: %v", changes)
+	}
+	if watcher.Current().DSN != "postgres://replica" {
+		t.Error("Current should return the reloaded config")
+	}
+}
+
+func TestConfigWatcherPollFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	container := NewContainer()
+	watcher, err := WatchConfig[testConfig](container, func() (testConfig, error) {
+		data, err := os.ReadFile(path)
+		return testConfig{DSN: string(data)}, err
+	})
+	if err != nil {
+		t.Fatalf("Failed to watch config: %v", err)
+	}
+
+	changed := make(chan string, 1)
+	watcher.OnChange(func(old, new testConfig) {
+		changed <- new.DSN
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.PollFile(ctx, path, 5*time.Millisecond)
+
+	if err := os.WriteFile(path, []byte("version 2"), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	select {
+	case dsn := <-changed:
+		if dsn != "version 2" {
+			t.Errorf("Expected the new file contents, got %q", dsn)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Config change was not picked up")
+	}
+}
@@ -0,0 +1,94 @@
+package inject
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// RunConsole serves an interactive debug console, reading one command per
+// line from in and writing results to out until in is exhausted or "quit"
+// is entered. It can be attached to stdin or to a local TCP connection, and
+// is meant for development only: it can construct and evict services.
+// Services it resolves are subject to the resolution policy, checked
+// against the caller of RunConsole.
+func (c *Container) RunConsole(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, "> ")
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			if fields[0] == "quit" || fields[0] == "exit" {
+				return nil
+			}
+			c.consoleCommand(out, fields[0], fields[1:])
+		}
+		fmt.Fprint(out, "> ")
+	}
+	return scanner.Err()
+}
+
+func (c *Container) consoleCommand(out io.Writer, command string, args []string) {
+	switch command {
+	case "help":
+		fmt.Fprintln(out, "commands: list, graph, stats, resolve <type>, evict <type>, quit")
+	case "list":
+		c.mu.RLock()
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		for _, descriptor := range c.sortedDescriptors() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", descriptor.displayName(), descriptor.Lifecycle, descriptor.Description)
+		}
+		w.Flush()
+		c.mu.RUnlock()
+	case "graph":
+		c.mu.RLock()
+		for _, descriptor := range c.sortedDescriptors() {
+			for _, dep := range c.dependenciesOf(descriptor) {
+				fmt.Fprintf(out, "%s -> %s\n", descriptor.ServiceType, dep)
+			}
+		}
+		c.mu.RUnlock()
+	case "stats":
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "SERVICE\tCREATED\tFAILURES\tLAST ERROR")
+		for _, s := range c.Stats().Services {
+			lastError := "-"
+			if s.LastError != nil {
+				lastError = s.LastError.Error()
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", s.ServiceType, s.Created, s.Failures, lastError)
+		}
+		w.Flush()
+	case "resolve", "evict":
+		if len(args) != 1 {
+			fmt.Fprintf(out, "usage: %s <type>\n", command)
+			return
+		}
+		c.mu.RLock()
+		descriptor, ok := c.descriptorByName(args[0])
+		c.mu.RUnlock()
+		if !ok {
+			fmt.Fprintf(out, "no service registered as %s\n", args[0])
+			return
+		}
+
+		if command == "evict" {
+			if err := c.evictSingleton(descriptor.ServiceType); err != nil {
+				fmt.Fprintf(out, "error: %v\n", err)
+				return
+			}
+			fmt.Fprintf(out, "evicted %s\n", descriptor.ServiceType)
+			return
+		}
+		instance, err := c.ResolveByType(descriptor.ServiceType)
+		if err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			return
+		}
+		fmt.Fprintf(out, "%T %+v\n", instance, instance)
+	default:
+		fmt.Fprintf(out, "unknown command %q, try help\n", command)
+	}
+}
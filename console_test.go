@@ -0,0 +1,81 @@
+package inject
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunConsole(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "console"}
+	}, WithDescription("test implementation"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	input := strings.Join([]string{
+		"list",
+		"graph",
+		"resolve inject.TestInterface",
+		"stats",
+		"evict inject.TestInterface",
+		"evict inject.Missing",
+		"bogus",
+		"quit",
+		"list",
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := container.RunConsole(strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Console failed: %v", err)
+	}
+
+	output := out.String()
+	for _, expected := range []string{
+		"inject.TestInterface  Singleton  test implementation",
+		"inject.TestService -> inject.TestInterface",
+		`*inject.TestImplementation &{value:console}`,
+		"inject.TestInterface  1        0",
+		"evicted inject.TestInterface",
+		"no service registered as inject.Missing",
+		`unknown command "bogus"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected console output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Count(output, "test implementation") != 1 {
+		t.Error("Console should stop reading after quit")
+	}
+}
+
+func TestRunConsoleChecksPolicy(t *testing.T) {
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		return errors.New("denied")
+	}))
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "console"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := container.RunConsole(strings.NewReader("resolve inject.TestInterface"), &out); err != nil {
+		t.Fatalf("Console failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "error: resolution of inject.TestInterface") {
+		t.Errorf("Expected the console to apply the resolution policy, got:\n%s", out.String())
+	}
+}
@@ -3,7 +3,10 @@ package inject
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Lifecycle int
@@ -11,31 +14,217 @@ type Lifecycle int
 const (
 	Transient Lifecycle = iota
 	Singleton
+	Pooled
+	// Keyed caches one instance per key, see RegisterKeyed
+	Keyed
+	// Scoped caches one instance per Scope, see NewScope
+	Scoped
 )
 
+func (l Lifecycle) String() string {
+	switch l {
+	case Transient:
+		return "Transient"
+	case Singleton:
+		return "Singleton"
+	case Pooled:
+		return "Pooled"
+	case Keyed:
+		return "Keyed"
+	case Scoped:
+		return "Scoped"
+	default:
+		return fmt.Sprintf("Lifecycle(%d)", int(l))
+	}
+}
+
+// serviceKey identifies a registration: its type and, for named
+// registrations, its name.
+type serviceKey struct {
+	serviceType reflect.Type
+	name        string
+	group       string
+	// seq tells apart additional registrations made with Append; the
+	// registration that Resolve returns has seq 0
+	seq uint64
+}
+
 type ServiceDescriptor struct {
 	ServiceType reflect.Type
+	// Name is set for named registrations, see WithName
+	Name string
+	// Group is set for the members of a group, see WithGroup
+	Group       string
 	Factory     interface{}
 	Lifecycle   Lifecycle
+	Description string
+	Metadata    map[string]interface{}
+	Tags        []string
 	instance    interface{}
 	mu          sync.RWMutex
+	// cleanup was returned by the factory along with instance
+	cleanup func()
+	// create is set by the generic helpers and lets resolution call the
+	// factory directly instead of through reflect.Value.Call
+	create func(*Container) (interface{}, error)
+	typed  interface{}
+	// methodExpr marks a method expression factory, whose receiver is
+	// resolved under either its pointer or its value type
+	methodExpr bool
+	// aliases are the additional types the instance is registered under
+	aliases []reflect.Type
+	// appended marks a registration made with Append, and seq is its
+	// place in the container
+	appended bool
+	seq      uint64
+	// registered orders registrations for ResolveAll
+	registered uint64
+	// keyed is the factory of a Keyed service, and keyedInstances its
+	// instances by key
+	keyed          func(*Container, string) (interface{}, error)
+	keyedInstances map[string]*flight
+	// decorators wrap each new instance, see Decorate
+	decorators []func(*Container, interface{}) interface{}
+	fallback   interface{}
+	retry      *RetryPolicy
+	failure    *singletonFailure
+	pool       *instancePool
+	inflight   *flight
+	built      uint64 // creation order of the instance, for disposal in reverse
+	stats      serviceStats
+	// dependents are the services whose factories resolved this one
+	depMu      sync.Mutex
+	dependents map[reflect.Type]struct{}
+	// eager singletons are built by Start, see WithEager
+	eager bool
 }
 
+const DefaultMaxResolutionDepth = 1000
+
+// Container is a handle on shared container state. Factories receive a
+// handle that also carries the resolution in progress, so nested lookups
+// made through it know the path that led to them.
 type Container struct {
-	services map[reflect.Type]*ServiceDescriptor
-	mu       sync.RWMutex
+	*containerCore
+	frame *resolveFrame
+	// scope is set on handles resolving within a Scope
+	scope *Scope
+	// caller is set on handles resolving on another goroutine for someone
+	// else, see withCaller
+	caller *policyCaller
+}
+
+type containerCore struct {
+	services          map[serviceKey]*ServiceDescriptor
+	mu                sync.RWMutex
+	pipelines         map[reflect.Type][]interface{}
+	events            *EventBus
+	eventsOnce        sync.Once
+	checkDependencies bool
+	maxDepth          int
+	noReflection      bool
+	audit             []AuditRecord
+	reporter          atomic.Pointer[ErrorReporter]
+	retry             *RetryPolicy
+	progress          func(InitEvent)
+	tracer            *tracer
+	tracks            atomic.Int64
+	memoryAccounting  bool
+	builds            atomic.Uint64
+	policy            ResolutionPolicy
+	generics          map[genericKey]*genericTemplate
+	replaceGrace      time.Duration
+	registrations     uint64
+	closed            atomic.Bool
+	resolveHook       atomic.Pointer[ResolveHook]
+	modulesMu         sync.Mutex
+	modules           map[string]bool
+	activeProfiles    map[string]bool
+	// pendingProfiles holds registrations for profiles not yet active
+	pendingProfiles map[string][]*ServiceDescriptor
+}
+
+// flight is a singleton construction in progress. Goroutines that need the
+// singleton meanwhile wait on done and share the outcome.
+type flight struct {
+	done     chan struct{}
+	instance interface{}
+	err      error
+	// built orders keyed instances for disposal
+	built uint64
+	// cleanup was returned by the factory with instance
+	cleanup func()
 }
 
-func NewContainer() *Container {
-	return &Container{
-		services: make(map[reflect.Type]*ServiceDescriptor),
+type resolveFrame struct {
+	serviceType reflect.Type
+	descriptor  *ServiceDescriptor
+	// key is the key of a keyed service under construction
+	key         string
+	parent      *resolveFrame
+	depth       int
+	track       int64
+	singleton   bool
+	nestedAlloc uint64
+	done        atomic.Bool
+}
+
+type ContainerOption func(*Container)
+
+func NewContainer(opts ...ContainerOption) *Container {
+	c := &Container{
+		containerCore: &containerCore{
+			services:  make(map[serviceKey]*ServiceDescriptor),
+			pipelines: make(map[reflect.Type][]interface{}),
+			maxDepth:  DefaultMaxResolutionDepth,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-func (c *Container) Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle) error {
+func WithNoReflection() ContainerOption {
+	return func(c *Container) {
+		c.noReflection = true
+	}
+}
+
+func WithMaxResolutionDepth(depth int) ContainerOption {
+	return func(c *Container) {
+		c.maxDepth = depth
+	}
+}
+
+func (c *Container) Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return c.register(serviceType, factory, lifecycle, nil, opts)
+}
+
+func (c *Container) register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	descriptor, err := c.newDescriptor(serviceType, factory, lifecycle, create, opts)
+	if err != nil {
+		return err
+	}
+	if c.checkDependencies {
+		if missing := c.missingDependencies(reflect.TypeOf(factory), descriptor.methodExpr); len(missing) > 0 {
+			return newError(ErrCodeMissingDependency, descriptor.ServiceType, "factory for %s depends on unregistered service %s", descriptor.ServiceType.String(), missing[0].String())
+		}
+	}
+	c.install(descriptor)
+	return nil
+}
+
+// newDescriptor validates a registration and builds its descriptor without
+// installing it.
+func (c *Container) newDescriptor(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) (*ServiceDescriptor, error) {
+	if c.noReflection && create == nil {
+		return nil, newError(ErrCodeReflectionDisabled, nil, "reflection-based registration is disabled; use the generic registration helpers")
+	}
+
 	sType := reflect.TypeOf(serviceType)
 	if sType.Kind() == reflect.Ptr {
 		sType = sType.Elem()
@@ -43,75 +232,186 @@ func (c *Container) Register(serviceType interface{}, factory interface{}, lifec
 
 	factoryType := reflect.TypeOf(factory)
 	if factoryType.Kind() != reflect.Func {
-		return fmt.Errorf("factory must be a function")
+		return nil, newError(ErrCodeInvalidFactory, sType, "factory must be a function")
 	}
 
-	if factoryType.NumOut() != 1 && factoryType.NumOut() != 2 {
-		return fmt.Errorf("factory function must return 1 or 2 values (service and optionally error)")
-	}
-
-	if factoryType.NumOut() == 2 {
-		errorInterface := reflect.TypeOf((*error)(nil)).Elem()
-		if !factoryType.Out(1).Implements(errorInterface) {
-			return fmt.Errorf("second return value must be an error")
+	if returnsCleanup(factoryType) {
+		if lifecycle != Singleton && lifecycle != Scoped {
+			return nil, newError(ErrCodeInvalidFactory, sType, "factories returning a cleanup function must be registered as Singleton or Scoped")
 		}
+	} else if err := validateFactoryResults(factoryType); err != nil {
+		return nil, err
 	}
 
-	returnType := factoryType.Out(0)
-
-	// Check type compatibility
-	if sType.Kind() == reflect.Interface {
-		// Service type is an interface, check if return type implements it
-		if !returnType.Implements(sType) {
-			return fmt.Errorf("factory return type %s does not implement interface %s", returnType.String(), sType.String())
-		}
-	} else {
-		// Service type is concrete, check for exact match or pointer compatibility
-		if returnType != sType {
-			if returnType.Kind() == reflect.Ptr && returnType.Elem() == sType {
-				// Pointer to service type is acceptable
-			} else if sType.Kind() == reflect.Ptr && sType.Elem() == returnType {
-				// Service type is pointer, return type is value
-			} else {
-				return fmt.Errorf("factory return type %s does not match service type %s", returnType.String(), sType.String())
-			}
-		}
+	if err := checkReturnType(sType, factoryType.Out(0)); err != nil {
+		return nil, err
 	}
 
+	methodExpr := create == nil && isMethodExpression(factory)
+
 	descriptor := &ServiceDescriptor{
 		ServiceType: sType,
 		Factory:     factory,
 		Lifecycle:   lifecycle,
+		create:      create,
+		methodExpr:  methodExpr,
+	}
+	for _, opt := range opts {
+		opt(descriptor)
 	}
 
-	c.services[sType] = descriptor
-	return nil
+	if descriptor.fallback != nil {
+		if err := c.checkFallback(descriptor); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkAliases(descriptor, factoryType.Out(0)); err != nil {
+		return nil, err
+	}
+
+	if lifecycle == Pooled && descriptor.pool == nil {
+		descriptor.pool = newInstancePool(PoolConfig{})
+	} else if lifecycle != Pooled && descriptor.pool != nil {
+		return nil, newError(ErrCodeInvalidArgument, sType, "WithPool requires the Pooled lifecycle")
+	}
+	if lifecycle == Keyed && descriptor.keyed == nil {
+		return nil, newError(ErrCodeInvalidArgument, sType, "keyed services must be registered with RegisterKeyed")
+	}
+	if descriptor.eager && lifecycle != Singleton {
+		return nil, newError(ErrCodeInvalidArgument, sType, "WithEager requires the Singleton lifecycle")
+	}
+
+	return descriptor, nil
+}
+
+func (c *Container) install(descriptor *ServiceDescriptor) {
+	c.registrations++
+	descriptor.registered = c.registrations
+	if descriptor.appended {
+		if _, exists := c.services[descriptor.key()]; exists {
+			descriptor.seq = c.registrations
+		}
+	}
+	key := descriptor.key()
+	displaced, replaced := c.services[key]
+	c.services[key] = descriptor
+	if replaced && c.replaceGrace > 0 {
+		c.disposeAfterGrace(displaced)
+	}
+	c.recordAudit(AuditRecord{
+		Action:      AuditRegister,
+		ServiceType: descriptor.ServiceType,
+		Lifecycle:   descriptor.Lifecycle,
+		Replaced:    replaced,
+	})
+	for _, alias := range descriptor.aliases {
+		c.install(newAliasDescriptor(alias, descriptor.key(), descriptor.appended))
+	}
 }
 
-func (c *Container) RegisterSingleton(serviceType interface{}, factory interface{}) error {
-	return c.Register(serviceType, factory, Singleton)
+func (c *Container) RegisterSingleton(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return c.Register(serviceType, factory, Singleton, opts...)
 }
 
-func (c *Container) RegisterTransient(serviceType interface{}, factory interface{}) error {
-	return c.Register(serviceType, factory, Transient)
+func (c *Container) RegisterTransient(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return c.Register(serviceType, factory, Transient, opts...)
 }
 
 func (c *Container) Resolve(serviceType interface{}) (interface{}, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	sType := serviceTypeOf(serviceType)
+	if err := c.checkPolicy(sType); err != nil {
+		return nil, err
+	}
+	return c.resolve(sType)
+}
 
-	sType := reflect.TypeOf(serviceType)
-	if sType.Kind() == reflect.Ptr {
-		sType = sType.Elem()
+// ResolveByType resolves the service registered under serviceType, for
+// callers that discover types at runtime instead of writing (*T)(nil). It
+// behaves exactly like Resolve, including from inside a factory.
+func (c *Container) ResolveByType(serviceType reflect.Type) (interface{}, error) {
+	if serviceType == nil {
+		return nil, newError(ErrCodeInvalidArgument, nil, "service type must not be nil")
+	}
+	if err := c.checkPolicy(serviceType); err != nil {
+		return nil, err
 	}
+	return c.resolve(serviceType)
+}
 
-	return c.resolveType(sType)
+func (c *Container) resolve(serviceType reflect.Type) (interface{}, error) {
+	defer c.readLock()()
+
+	return c.resolveType(serviceType)
+}
+
+// readLock takes the read lock unless this handle belongs to a resolution
+// that is still running, whose caller already holds it. Taking it again
+// would deadlock with a waiting Register, because RWMutex blocks new readers
+// once a writer is waiting.
+func (c *Container) readLock() (unlock func()) {
+	if c.frame != nil && !c.frame.done.Load() {
+		return func() {}
+	}
+	c.mu.RLock()
+	return c.mu.RUnlock
 }
 
 func (c *Container) resolveType(serviceType reflect.Type) (interface{}, error) {
-	descriptor, exists := c.services[serviceType]
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType}]
 	if !exists {
-		return nil, fmt.Errorf("service of type %s not registered", serviceType.String())
+		if bidirectional, ok := c.channelKey(serviceType); ok {
+			return c.resolveChannel(serviceType, bidirectional)
+		}
+		if target, ok := lazyKey(serviceType); ok {
+			return c.resolveLazy(serviceType, target)
+		}
+		if elem, ok := c.collectionKey(serviceType); ok {
+			return c.resolveCollection(serviceType, elem)
+		}
+		if descriptor, exists = c.genericDescriptor(serviceType); !exists {
+			return nil, c.resolutionError(serviceType, c.notRegisteredError(serviceType))
+		}
+	}
+	return c.resolveDescriptor(descriptor)
+}
+
+func (c *Container) resolveDescriptor(descriptor *ServiceDescriptor) (interface{}, error) {
+	hook := c.resolveHook.Load()
+	if hook == nil {
+		instance, _, err := c.resolveInstance(descriptor)
+		return instance, c.resolutionError(descriptor.ServiceType, err)
+	}
+
+	start := time.Now()
+	instance, cached, err := c.resolveInstance(descriptor)
+	(*hook)(c.resolveEvent(descriptor, cached, start, err))
+	return instance, c.resolutionError(descriptor.ServiceType, err)
+}
+
+// resolveInstance resolves descriptor, reporting whether the instance was
+// already built.
+func (c *Container) resolveInstance(descriptor *ServiceDescriptor) (interface{}, bool, error) {
+	serviceType := descriptor.ServiceType
+	c.recordDependency(descriptor)
+
+	if c.closed.Load() {
+		return nil, false, errContainerClosed(serviceType)
+	}
+	if err := c.checkCycle(descriptor, ""); err != nil {
+		return nil, false, err
+	}
+	if descriptor.Lifecycle == Pooled {
+		return nil, false, newError(ErrCodeInvalidArgument, serviceType, "service of type %s is pooled; check it out with Acquire", serviceType.String())
+	}
+	if descriptor.Lifecycle == Keyed {
+		return nil, false, newError(ErrCodeInvalidArgument, serviceType, "service of type %s is keyed; resolve it with ResolveKeyed", serviceType.String())
+	}
+	if descriptor.Lifecycle == Scoped {
+		if c.scope == nil {
+			return nil, false, c.scopeMismatch(serviceType)
+		}
+		return c.scope.resolve(c, descriptor)
 	}
 
 	if descriptor.Lifecycle == Singleton {
@@ -119,30 +419,192 @@ func (c *Container) resolveType(serviceType reflect.Type) (interface{}, error) {
 		if descriptor.instance != nil {
 			instance := descriptor.instance
 			descriptor.mu.RUnlock()
-			return instance, nil
+			return instance, true, nil
 		}
 		descriptor.mu.RUnlock()
 
 		descriptor.mu.Lock()
-		defer descriptor.mu.Unlock()
-
 		if descriptor.instance != nil {
-			return descriptor.instance, nil
+			instance := descriptor.instance
+			descriptor.mu.Unlock()
+			return instance, true, nil
 		}
 
-		instance, err := c.createInstance(descriptor)
-		if err != nil {
-			return nil, err
+		// Another goroutine is already building this singleton; share its
+		// result instead of running the factory again
+		if f := descriptor.inflight; f != nil {
+			descriptor.mu.Unlock()
+			start := time.Now()
+			<-f.done
+			descriptor.stats.recordWait(time.Since(start))
+			return f.instance, true, f.err
 		}
-		descriptor.instance = instance
-		return instance, nil
+		f := &flight{done: make(chan struct{})}
+		descriptor.inflight = f
+		descriptor.mu.Unlock()
+
+		f.instance, f.err = c.constructSingleton(descriptor)
+
+		descriptor.mu.Lock()
+		closed := c.closed.Load()
+		if f.err == nil && !closed {
+			descriptor.instance = f.instance
+			descriptor.cleanup = f.cleanup
+			descriptor.built = c.builds.Add(1)
+		}
+		descriptor.inflight = nil
+		descriptor.mu.Unlock()
+		if f.err == nil && closed {
+			f.err = disposeLate(serviceType, f.instance)
+			f.instance = nil
+		}
+		close(f.done)
+		if f.err != nil {
+			runCleanup(f.cleanup)
+		}
+
+		return f.instance, false, f.err
 	}
 
-	return c.createInstance(descriptor)
+	instance, err := c.construct(descriptor)
+	return instance, false, err
+}
+
+func (c *Container) construct(descriptor *ServiceDescriptor) (interface{}, error) {
+	child, err := c.enter(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	defer child.frame.done.Store(true)
+
+	if descriptor.Lifecycle == Singleton {
+		// Singletons outlive every scope, so they must not capture scoped
+		// services
+		child.scope = nil
+	}
+	if descriptor.Lifecycle == Singleton && (c.tracer != nil || c.memoryAccounting) {
+		return child.createInstrumented(descriptor)
+	}
+	return child.createInstance(descriptor)
+}
+
+func (c *Container) createInstrumented(descriptor *ServiceDescriptor) (interface{}, error) {
+	c.frame.singleton = true
+	start := time.Now()
+	var before uint64
+	if c.memoryAccounting {
+		before = heapAllocated()
+	}
+
+	instance, err := c.createInstance(descriptor)
+
+	if c.memoryAccounting && err == nil {
+		c.frame.accountMemory(descriptor, heapAllocated()-before)
+	}
+	if c.tracer != nil {
+		c.tracer.record(descriptor.ServiceType.String(), "construct", c.frame.track, start, err)
+	}
+	return instance, err
+}
+
+// enter returns a handle whose frame records descriptor as the next step of
+// the resolution path. Callers mark the frame done once the factory returns.
+func (c *Container) enter(descriptor *ServiceDescriptor) (*Container, error) {
+	parent := c.frame
+	// A handle kept by a service after its factory returned no longer
+	// belongs to an in-flight resolution
+	if parent != nil && parent.done.Load() {
+		parent = nil
+	}
+
+	frame := &resolveFrame{serviceType: descriptor.ServiceType, descriptor: descriptor, parent: parent, depth: 1}
+	if parent != nil {
+		frame.depth = parent.depth + 1
+		frame.track = parent.track
+	} else if c.tracer != nil {
+		frame.track = c.tracks.Add(1)
+	}
+	if c.maxDepth > 0 && frame.depth > c.maxDepth {
+		return nil, newError(ErrCodeDepthExceeded, descriptor.ServiceType, "maximum resolution depth of %d exceeded: %s", c.maxDepth, frame.path())
+	}
+
+	return &Container{containerCore: c.containerCore, frame: frame, scope: c.scope, caller: c.caller}, nil
+}
+
+// checkCycle fails if the resolution in progress on this handle is already
+// building descriptor, which would otherwise recurse until the depth limit
+// or wait forever on its own singleton.
+func (c *Container) checkCycle(descriptor *ServiceDescriptor, key string) error {
+	if c.frame == nil || c.frame.done.Load() {
+		return nil
+	}
+	for frame := c.frame; frame != nil; frame = frame.parent {
+		if frame.descriptor == descriptor && frame.key == key {
+			return newError(ErrCodeCycle, descriptor.ServiceType, "circular dependency: %s -> %s", c.frame.path(), descriptor.ServiceType.String())
+		}
+	}
+	return nil
+}
+
+func (f *resolveFrame) path() string {
+	var names []string
+	for _, serviceType := range f.types() {
+		names = append(names, serviceType.String())
+	}
+	return strings.Join(names, " -> ")
+}
+
+// types returns the resolution path from the outermost request down to f.
+func (f *resolveFrame) types() []reflect.Type {
+	var types []reflect.Type
+	for frame := f; frame != nil; frame = frame.parent {
+		types = append(types, frame.serviceType)
+	}
+	for i, j := 0, len(types)-1; i < j; i, j = i+1, j-1 {
+		types[i], types[j] = types[j], types[i]
+	}
+	return types
 }
 
 func (c *Container) createInstance(descriptor *ServiceDescriptor) (interface{}, error) {
-	factoryValue := reflect.ValueOf(descriptor.Factory)
+	instance, err := c.callFactory(descriptor, descriptor.Factory, descriptor.create, descriptor.methodExpr)
+	if err == nil {
+		descriptor.stats.setDegraded(nil)
+		return c.decorate(descriptor, instance)
+	}
+	if descriptor.fallback == nil {
+		return nil, err
+	}
+
+	instance, fallbackErr := c.callFactory(descriptor, descriptor.fallback, nil, isMethodExpression(descriptor.fallback))
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("%w (fallback also failed: %w)", err, fallbackErr)
+	}
+	descriptor.stats.setDegraded(err)
+	return c.decorate(descriptor, instance)
+}
+
+func (c *Container) callFactory(descriptor *ServiceDescriptor, factory interface{}, create func(*Container) (interface{}, error), methodExpr bool) (instance interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			instance = nil
+			err = newError(ErrCodeFactoryPanic, descriptor.ServiceType, "factory for %s panicked: %v", descriptor.ServiceType.String(), r)
+			c.factoryFailed(descriptor, err)
+		}
+	}()
+
+	if create != nil {
+		instance, err := create(c)
+		if err != nil {
+			err = factoryError(descriptor.ServiceType, err)
+			c.factoryFailed(descriptor, err)
+			return nil, err
+		}
+		descriptor.stats.created.Add(1)
+		return instance, nil
+	}
+
+	factoryValue := reflect.ValueOf(factory)
 	factoryType := factoryValue.Type()
 
 	args := make([]reflect.Value, factoryType.NumIn())
@@ -153,21 +615,50 @@ func (c *Container) createInstance(descriptor *ServiceDescriptor) (interface{},
 			args[i] = reflect.ValueOf(c)
 			continue
 		}
+		if argType == contextType {
+			args[i] = reflect.ValueOf(c.context())
+			continue
+		}
+
+		if i == 0 && methodExpr {
+			receiver, err := c.resolveReceiver(argType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve receiver %s: %w", argType.String(), err)
+			}
+			args[i] = receiver
+			continue
+		}
+
+		if isParamStruct(argType) {
+			params, err := c.resolveParams(argType)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = params
+			continue
+		}
 
 		arg, err := c.resolveType(argType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve dependency %s: %w", argType.String(), err)
+			return nil, dependencyError(argType, err)
 		}
 		args[i] = reflect.ValueOf(arg)
 	}
 
 	results := factoryValue.Call(args)
 
-	if len(results) == 2 {
-		if !results[1].IsNil() {
-			return nil, results[1].Interface().(error)
+	if len(results) > 1 {
+		if last := results[len(results)-1]; !last.IsNil() {
+			err := factoryError(descriptor.ServiceType, last.Interface().(error))
+			c.factoryFailed(descriptor, err)
+			return nil, err
 		}
 	}
+	if len(results) == 3 {
+		c.keepCleanup(descriptor, results[1].Interface().(func()))
+	}
+
+	descriptor.stats.created.Add(1)
 
 	return results[0].Interface(), nil
 }
@@ -175,5 +666,53 @@ func (c *Container) createInstance(descriptor *ServiceDescriptor) (interface{},
 func (c *Container) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.services = make(map[reflect.Type]*ServiceDescriptor)
+	c.services = make(map[serviceKey]*ServiceDescriptor)
+	c.pipelines = make(map[reflect.Type][]interface{})
+	c.generics = nil
+	c.pendingProfiles = nil
+	c.recordAudit(AuditRecord{Action: AuditClear})
+}
+
+func checkReturnType(sType reflect.Type, returnType reflect.Type) error {
+	// Check type compatibility
+	if sType.Kind() == reflect.Interface {
+		// Service type is an interface, check if return type implements it
+		if !returnType.Implements(sType) {
+			return newError(ErrCodeTypeMismatch, sType, "factory return type %s does not implement interface %s", returnType.String(), sType.String())
+		}
+	} else {
+		// Service type is concrete, check for exact match or pointer compatibility
+		if returnType != sType {
+			if returnType.Kind() == reflect.Ptr && returnType.Elem() == sType {
+				// Pointer to service type is acceptable
+			} else if sType.Kind() == reflect.Ptr && sType.Elem() == returnType {
+				// Service type is pointer, return type is value
+			} else {
+				return newError(ErrCodeTypeMismatch, sType, "factory return type %s does not match service type %s", returnType.String(), sType.String())
+			}
+		}
+	}
+	return nil
+}
+
+func validateFactoryResults(factoryType reflect.Type) error {
+	if factoryType.NumOut() != 1 && factoryType.NumOut() != 2 {
+		return newError(ErrCodeInvalidFactory, nil, "factory function must return 1 or 2 values (service and optionally error)")
+	}
+
+	if factoryType.NumOut() == 2 {
+		errorInterface := reflect.TypeOf((*error)(nil)).Elem()
+		if !factoryType.Out(1).Implements(errorInterface) {
+			return newError(ErrCodeInvalidFactory, nil, "second return value must be an error")
+		}
+	}
+	return nil
+}
+
+func serviceTypeOf(serviceType interface{}) reflect.Type {
+	sType := reflect.TypeOf(serviceType)
+	if sType.Kind() == reflect.Ptr {
+		sType = sType.Elem()
+	}
+	return sType
 }
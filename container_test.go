@@ -2,7 +2,13 @@ package inject
 
 import (
 	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type TestInterface interface {
@@ -195,6 +201,35 @@ func TestUnregisteredService(t *testing.T) {
 	}
 }
 
+func TestResolveByType(t *testing.T) {
+	container := NewContainer()
+
+	err := container.Register((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "by type"}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	service, err := container.ResolveByType(reflect.TypeFor[TestInterface]())
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service != MustResolve[TestInterface](container) {
+		t.Error("Expected ResolveByType to share the singleton with Resolve")
+	}
+
+	_, err = container.ResolveByType(reflect.TypeFor[*TestService]())
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	_, err = container.ResolveByType(nil)
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a nil type, got %v", err)
+	}
+}
+
 func TestInvalidFactory(t *testing.T) {
 	container := NewContainer()
 
@@ -305,3 +340,310 @@ func TestGetServiceTypes(t *testing.T) {
 		t.Errorf("Expected 2 service types, got %d", len(types))
 	}
 }
+
+type TestRecursiveService struct {
+	next *TestRecursiveService
+}
+
+func TestMaxResolutionDepth(t *testing.T) {
+	container := NewContainer(WithMaxResolutionDepth(5))
+
+	// Each key depends on the one below it, so the chain is deep without
+	// being circular
+	err := RegisterKeyed[*TestRecursiveService](container, func(c *Container, key string) (*TestRecursiveService, error) {
+		n, _ := strconv.Atoi(key)
+		if n == 0 {
+			return &TestRecursiveService{}, nil
+		}
+		next, err := ResolveKeyed[*TestRecursiveService](c, strconv.Itoa(n-1))
+		if err != nil {
+			return nil, err
+		}
+		return &TestRecursiveService{next: next}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = ResolveKeyed[*TestRecursiveService](container, "10")
+	if err == nil {
+		t.Fatal("Expected error when resolution depth is exceeded")
+	}
+	if !strings.Contains(err.Error(), "maximum resolution depth of 5 exceeded") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	path := err.Error()[strings.Index(err.Error(), "exceeded: ")+len("exceeded: "):]
+	if strings.Count(path, "*inject.TestRecursiveService") != 6 {
+		t.Errorf("Error should list the full resolution path, got '%s'", err.Error())
+	}
+}
+
+func TestMaxResolutionDepthThroughContainer(t *testing.T) {
+	container := NewContainer(WithMaxResolutionDepth(3))
+
+	chain := []interface{}{(**TestImplementation)(nil), (**TestService)(nil), (**TestRepository)(nil), (**TestRecursiveService)(nil)}
+	factories := []interface{}{
+		func(c *Container) (*TestImplementation, error) {
+			_, err := c.Resolve(chain[1])
+			return &TestImplementation{}, err
+		},
+		func(c *Container) (*TestService, error) {
+			_, err := c.Resolve(chain[2])
+			return &TestService{}, err
+		},
+		func(c *Container) (*TestRepository, error) {
+			_, err := c.Resolve(chain[3])
+			return &TestRepository{}, err
+		},
+		func(c *Container) *TestRecursiveService {
+			return &TestRecursiveService{}
+		},
+	}
+	for i := range chain {
+		if err := container.RegisterTransient(chain[i], factories[i]); err != nil {
+			t.Fatalf("Failed to register service: %v", err)
+		}
+	}
+
+	_, err := container.Resolve(chain[0])
+	if err == nil || !strings.Contains(err.Error(), "maximum resolution depth of 3 exceeded") {
+		t.Errorf("Expected depth error through container injection, got %v", err)
+	}
+}
+
+func TestCircularDependency(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((**TestService)(nil), func(c *Container) (*TestService, error) {
+		_, err := c.Resolve((**TestRepository)(nil))
+		return &TestService{}, err
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((**TestRepository)(nil), func(svc *TestService) *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	_, err = container.Resolve((**TestService)(nil))
+	if ErrorCodeOf(err) != ErrCodeCycle {
+		t.Fatalf("Expected %s, got %v", ErrCodeCycle, err)
+	}
+	if !strings.Contains(err.Error(), "*inject.TestService -> *inject.TestRepository -> *inject.TestService") {
+		t.Errorf("Error should show the cycle, got '%s'", err.Error())
+	}
+
+	// A failed singleton is not cached, so the cycle is reported again
+	if _, err := container.Resolve((**TestRepository)(nil)); ErrorCodeOf(err) != ErrCodeCycle {
+		t.Errorf("Expected %s, got %v", ErrCodeCycle, err)
+	}
+}
+
+func TestStoredContainerStartsFreshResolution(t *testing.T) {
+	container := NewContainer(WithMaxResolutionDepth(1))
+
+	err := container.RegisterSingleton((*TestRepository)(nil), func(c *Container) *TestRepository {
+		return &TestRepository{data: make(map[string]string)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	var stored *Container
+	err = container.RegisterSingleton((*TestImplementation)(nil), func(c *Container) *TestImplementation {
+		stored = c
+		return &TestImplementation{value: "stored"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*TestImplementation)(nil)); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+
+	if _, err := stored.Resolve((*TestRepository)(nil)); err != nil {
+		t.Errorf("Container kept after construction should resolve from a fresh path: %v", err)
+	}
+}
+
+func TestNoReflectionMode(t *testing.T) {
+	container := NewContainer(WithNoReflection())
+
+	err := container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{value: "reflect"}
+	})
+	if err == nil {
+		t.Error("Expected error for reflection-based registration in no-reflection mode")
+	}
+
+	err = container.RegisterFunc(func() *TestImplementation {
+		return &TestImplementation{value: "reflect"}
+	}, Transient)
+	if err == nil {
+		t.Error("Expected error for RegisterFunc in no-reflection mode")
+	}
+
+	err = RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "typed"}
+	})
+	if err != nil {
+		t.Fatalf("Typed registration should be allowed in no-reflection mode: %v", err)
+	}
+
+	err = RegisterValue[*TestRepository](container, &TestRepository{data: make(map[string]string)})
+	if err != nil {
+		t.Fatalf("Value registration should be allowed in no-reflection mode: %v", err)
+	}
+
+	if MustResolve[TestInterface](container).GetValue() != "typed" {
+		t.Error("Typed registration should resolve in no-reflection mode")
+	}
+
+	if err := Populate(container, &TestResolverRoot{}); err == nil {
+		t.Error("Expected error for field injection in no-reflection mode")
+	}
+}
+
+func TestGenericHelpersBypassReflectiveCall(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "typed"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register type: %v", err)
+	}
+
+	descriptor := container.services[serviceKey{serviceType: reflect.TypeOf((*TestImplementation)(nil))}]
+	if descriptor == nil || descriptor.create == nil {
+		t.Error("Generic helpers should record a typed constructor")
+	}
+}
+
+func TestReregistrationDuringResolution(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "old"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+	started, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	err = container.RegisterTransient((*TestService)(nil), func(c *Container) *TestService {
+		once.Do(func() {
+			close(started)
+			<-release
+		})
+		return &TestService{dependency: MustResolve[TestInterface](c)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	inflight := make(chan interface{})
+	go func() {
+		service, _ := container.Resolve((*TestService)(nil))
+		inflight <- service
+	}()
+	<-started
+
+	// The replacement waits for the in-flight resolution, which must still be
+	// able to resolve its dependencies
+	registered := make(chan error)
+	go func() {
+		registered <- container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+			return &TestImplementation{value: "new"}
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case service := <-inflight:
+		if service.(*TestService).GetDependency().GetValue() != "old" {
+			t.Error("An in-flight resolution should finish against the old registration")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Resolution deadlocked against a pending registration")
+	}
+	if err := <-registered; err != nil {
+		t.Fatalf("Failed to re-register dependency: %v", err)
+	}
+
+	service, err := container.Resolve((*TestService)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service.(*TestService).GetDependency().GetValue() != "new" {
+		t.Error("New resolutions should see the replacement")
+	}
+}
+
+func TestConcurrentSingletonConstructionIsCoalesced(t *testing.T) {
+	container := NewContainer()
+
+	var dependencyCalls, serviceCalls atomic.Int32
+	release := make(chan struct{})
+	err := container.RegisterSingleton((*TestInterface)(nil), func() (TestInterface, error) {
+		dependencyCalls.Add(1)
+		<-release
+		return nil, errors.New("dependency unavailable")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func(dep TestInterface) *TestService {
+		serviceCalls.Add(1)
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := container.Resolve((*TestService)(nil))
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "dependency unavailable") {
+			t.Errorf("Waiters should share the failure, got %v", err)
+		}
+	}
+	if dependencyCalls.Load() != 1 || serviceCalls.Load() != 0 {
+		t.Errorf("Expected a single construction attempt, got %d", dependencyCalls.Load())
+	}
+
+	stats := container.Stats()
+	var waits uint64
+	var waitTime time.Duration
+	for _, service := range stats.Services {
+		waits += service.Waits
+		waitTime += service.WaitTime
+	}
+	if waits != 9 || waitTime == 0 {
+		t.Errorf("Expected 9 recorded waits, got %d (%s)", waits, waitTime)
+	}
+
+	// The failure is not cached, so the next resolve runs the factory again
+	container.Resolve((*TestService)(nil))
+	if dependencyCalls.Load() != 2 {
+		t.Error("A failed construction should be retried on the next resolve")
+	}
+}
@@ -0,0 +1,66 @@
+// Package clock registers a Clock abstraction with a go-inject container, so
+// services that depend on time can be tested against a Fake clock.
+package clock
+
+import (
+	"time"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+// Clock is the subset of the time package that services depend on.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Register registers the real clock as the container's Clock.
+func Register(container inject.Registrar) error {
+	return inject.RegisterValue[Clock](container, Real())
+}
+
+// RegisterFake registers a Fake clock starting at start, replacing any Clock
+// already registered, and returns it so tests can move time forward.
+func RegisterFake(container inject.Registrar, start time.Time) (*Fake, error) {
+	fake := NewFake(start)
+	if err := inject.RegisterValue[Clock](container, fake); err != nil {
+		return nil, err
+	}
+	return fake, nil
+}
+
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t realTicker) Stop() {
+	t.ticker.Stop()
+}
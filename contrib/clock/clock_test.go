@@ -0,0 +1,108 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+type session struct {
+	clock   Clock
+	expires time.Time
+}
+
+func (s *session) Expired() bool {
+	return !s.clock.Now().Before(s.expires)
+}
+
+func TestRegisterFake(t *testing.T) {
+	container := inject.NewContainer()
+	if err := Register(container); err != nil {
+		t.Fatalf("Failed to register clock: %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake, err := RegisterFake(container, start)
+	if err != nil {
+		t.Fatalf("Failed to register fake clock: %v", err)
+	}
+	err = container.RegisterTransient((*session)(nil), func(clock Clock) *session {
+		return &session{clock: clock, expires: clock.Now().Add(time.Hour)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+
+	resolved, err := container.Resolve((*session)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve session: %v", err)
+	}
+	s := resolved.(*session)
+	if s.Expired() {
+		t.Fatal("Session should not be expired yet")
+	}
+	fake.Advance(time.Hour)
+	if !s.Expired() {
+		t.Error("Session should expire once the fake clock passes its expiry")
+	}
+}
+
+func TestFakeAfter(t *testing.T) {
+	fake := NewFake(time.Unix(0, 0))
+	ch := fake.After(time.Minute)
+
+	fake.Advance(59 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After fired early")
+	default:
+	}
+
+	fake.Advance(time.Second)
+	select {
+	case at := <-ch:
+		if !at.Equal(time.Unix(60, 0)) {
+			t.Errorf("Expected After to fire at 60s, got %v", at)
+		}
+	default:
+		t.Fatal("After should fire once its time is reached")
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	fake := NewFake(time.Unix(0, 0))
+	ticker := fake.NewTicker(time.Second)
+
+	var ticks int
+	for i := 0; i < 3; i++ {
+		fake.Advance(time.Second)
+		select {
+		case <-ticker.C():
+			ticks++
+		default:
+		}
+	}
+	if ticks != 3 {
+		t.Errorf("Expected 3 ticks, got %d", ticks)
+	}
+
+	ticker.Stop()
+	fake.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Error("A stopped ticker should not tick")
+	default:
+	}
+}
+
+func TestRealClock(t *testing.T) {
+	clock := Real()
+	if clock.Now().IsZero() {
+		t.Error("Real clock should report the current time")
+	}
+	<-clock.After(time.Millisecond)
+	ticker := clock.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
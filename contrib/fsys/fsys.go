@@ -0,0 +1,96 @@
+// Package fsys registers named filesystems with a go-inject container.
+// Services depend on *Filesystems and look up the filesystem they need by
+// name, so tests can swap any of them for an in-memory one.
+package fsys
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+// WritableFS is a filesystem that services can also write to.
+type WritableFS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+}
+
+// Filesystems holds the filesystems registered by name.
+type Filesystems struct {
+	mu     sync.RWMutex
+	byName map[string]fs.FS
+}
+
+// Register makes fsys available under name, replacing any filesystem
+// already registered under it. The first call registers *Filesystems with
+// the container.
+func Register(container *inject.Container, name string, fsys fs.FS) error {
+	if !container.Has((**Filesystems)(nil)) {
+		err := inject.RegisterValue(container, &Filesystems{byName: make(map[string]fs.FS)})
+		if err != nil {
+			return err
+		}
+	}
+	resolved, err := container.Resolve((**Filesystems)(nil))
+	if err != nil {
+		return err
+	}
+	filesystems := resolved.(*Filesystems)
+
+	filesystems.mu.Lock()
+	defer filesystems.mu.Unlock()
+	filesystems.byName[name] = fsys
+	return nil
+}
+
+// RegisterMemory registers an empty in-memory filesystem under name and
+// returns it, typically to override a real filesystem in tests.
+func RegisterMemory(container *inject.Container, name string) (WritableFS, error) {
+	memory := Memory()
+	if err := Register(container, name, memory); err != nil {
+		return nil, err
+	}
+	return memory, nil
+}
+
+func (f *Filesystems) Get(name string) (fs.FS, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	fsys, ok := f.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("no filesystem registered as %q", name)
+	}
+	return fsys, nil
+}
+
+// Writable returns the filesystem registered as name, failing if it is
+// read-only, like an embed.FS.
+func (f *Filesystems) Writable(name string) (WritableFS, error) {
+	fsys, err := f.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	writable, ok := fsys.(WritableFS)
+	if !ok {
+		return nil, fmt.Errorf("filesystem %q is read-only", name)
+	}
+	return writable, nil
+}
+
+func (f *Filesystems) Names() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	names := make([]string, 0, len(f.byName))
+	for name := range f.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,97 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+type uploader struct {
+	files WritableFS
+}
+
+func newUploader(filesystems *Filesystems) (*uploader, error) {
+	files, err := filesystems.Writable("uploads")
+	if err != nil {
+		return nil, err
+	}
+	return &uploader{files: files}, nil
+}
+
+func TestRegisterMemoryOverride(t *testing.T) {
+	container := inject.NewContainer()
+	if err := Register(container, "uploads", OS(t.TempDir())); err != nil {
+		t.Fatalf("Failed to register filesystem: %v", err)
+	}
+	if err := Register(container, "assets", fstest.MapFS{"logo.svg": {Data: []byte("<svg/>")}}); err != nil {
+		t.Fatalf("Failed to register filesystem: %v", err)
+	}
+	memory, err := RegisterMemory(container, "uploads")
+	if err != nil {
+		t.Fatalf("Failed to register memory filesystem: %v", err)
+	}
+	err = container.RegisterSingleton((*uploader)(nil), newUploader)
+	if err != nil {
+		t.Fatalf("Failed to register uploader: %v", err)
+	}
+
+	resolved, err := container.Resolve((*uploader)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve uploader: %v", err)
+	}
+	if err := resolved.(*uploader).files.WriteFile("report.txt", []byte("done"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	data, err := fs.ReadFile(memory, "report.txt")
+	if err != nil || string(data) != "done" {
+		t.Errorf("Expected the uploader to write to the memory override, got %q (%v)", data, err)
+	}
+
+	filesystems := inject.MustResolve[*Filesystems](container)
+	if _, err := filesystems.Writable("assets"); err == nil {
+		t.Error("Expected a read-only filesystem to be rejected by Writable")
+	}
+	if _, err := filesystems.Get("missing"); err == nil {
+		t.Error("Expected an error for an unregistered filesystem")
+	}
+	if names := filesystems.Names(); len(names) != 2 || names[0] != "assets" || names[1] != "uploads" {
+		t.Errorf("Expected assets and uploads, got %v", names)
+	}
+}
+
+func testWritable(t *testing.T, fsys WritableFS) {
+	t.Helper()
+
+	if err := fsys.WriteFile("dir/a.txt", []byte("a"), 0o644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected writing into a missing directory to fail, got %v", err)
+	}
+	if err := fsys.MkdirAll("dir/sub", 0o755); err != nil {
+		t.Fatalf("Failed to create directories: %v", err)
+	}
+	if err := fsys.WriteFile("dir/sub/a.txt", []byte("a"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := fstest.TestFS(fsys, "dir/sub/a.txt"); err != nil {
+		t.Errorf("Filesystem does not behave like an fs.FS: %v", err)
+	}
+	if err := fsys.WriteFile("../escape.txt", nil, 0o644); err == nil {
+		t.Error("Expected an invalid path to be rejected")
+	}
+	if err := fsys.Remove("dir/sub/a.txt"); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	if _, err := fs.Stat(fsys, "dir/sub/a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the removed file to be gone, got %v", err)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testWritable(t, Memory())
+}
+
+func TestOS(t *testing.T) {
+	testWritable(t, OS(t.TempDir()))
+}
@@ -0,0 +1,82 @@
+package fsys
+
+import (
+	"io/fs"
+	"path"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+// Memory returns an empty in-memory filesystem that is safe for concurrent
+// use.
+func Memory() WritableFS {
+	return &memoryFS{files: fstest.MapFS{}}
+}
+
+type memoryFS struct {
+	mu    sync.RWMutex
+	files fstest.MapFS
+}
+
+func (m *memoryFS) Open(name string) (fs.File, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.Open(name)
+}
+
+func (m *memoryFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if dir := path.Dir(name); dir != "." && !m.isDir(dir) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+	}
+	// Files are replaced rather than modified, so readers holding the old
+	// one are unaffected
+	m.files[name] = &fstest.MapFile{Data: append([]byte(nil), data...), Mode: perm, ModTime: time.Now()}
+	return nil
+}
+
+func (m *memoryFS) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		if file, ok := m.files[dir]; ok && !file.Mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+		}
+		m.files[dir] = &fstest.MapFile{Mode: fs.ModeDir | perm, ModTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *memoryFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	prefix := name + "/"
+	for other := range m.files {
+		if len(other) > len(prefix) && other[:len(prefix)] == prefix {
+			return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+		}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// isDir reports whether dir exists, either created by MkdirAll or implied
+// by the files beneath it.
+func (m *memoryFS) isDir(dir string) bool {
+	info, err := fs.Stat(m.files, dir)
+	return err == nil && info.IsDir()
+}
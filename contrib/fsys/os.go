@@ -0,0 +1,49 @@
+package fsys
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OS returns the directory tree rooted at dir. Names are slash-separated
+// and relative to dir, as fs.ValidPath requires.
+func OS(dir string) WritableFS {
+	return osFS{FS: os.DirFS(dir), dir: dir}
+}
+
+type osFS struct {
+	fs.FS
+	dir string
+}
+
+func (f osFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(f.dir, filepath.FromSlash(name)), nil
+}
+
+func (f osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	path, err := f.path("write", name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+func (f osFS) MkdirAll(name string, perm fs.FileMode) error {
+	path, err := f.path("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(path, perm)
+}
+
+func (f osFS) Remove(name string) error {
+	path, err := f.path("remove", name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,199 @@
+// Package httpserver registers a managed *http.Server with a go-inject
+// container. Modules contribute routes and middleware to groups with Handle
+// and Use, and the server mounts them all when it is built.
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+// Config configures the server. Zero timeouts fall back to the defaults
+// below rather than to net/http's unlimited ones.
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ShutdownTimeout bounds how long Stop waits for in-flight requests.
+	ShutdownTimeout time.Duration
+	// TLSCertFile and TLSKeyFile enable TLS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+const (
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultIdleTimeout       = 2 * time.Minute
+	DefaultShutdownTimeout   = 30 * time.Second
+)
+
+// Groups the server collects its routes and middleware from.
+const (
+	RoutesGroup     = "httpserver.routes"
+	MiddlewareGroup = "httpserver.middleware"
+)
+
+// Route is a handler mounted on a http.ServeMux pattern.
+type Route struct {
+	Pattern string
+	Handler http.Handler
+}
+
+// Middleware wraps the handler of the server.
+type Middleware func(http.Handler) http.Handler
+
+// Register registers the *Server, built from config and every route and
+// middleware contributed to RoutesGroup and MiddlewareGroup, along with the
+// routes contributed with inject.RegisterRoute. Contributions are collected
+// when the server is built, so make them before it is first resolved, or
+// before App.Start. When the container has an inject.App, the server starts
+// and stops with it.
+func Register(container inject.Registrar, config Config) error {
+	return container.RegisterSingleton((**Server)(nil), func(c *inject.Container) (*Server, error) {
+		routes, err := inject.ResolveGroup[Route](c, RoutesGroup)
+		if err != nil {
+			return nil, err
+		}
+		middleware, err := inject.ResolveGroup[Middleware](c, MiddlewareGroup)
+		if err != nil {
+			return nil, err
+		}
+		mux := http.NewServeMux()
+		for _, r := range routes {
+			mux.Handle(r.Pattern, r.Handler)
+		}
+		if err := inject.MountRoutes(c, mux); err != nil {
+			return nil, err
+		}
+		server := newServer(config, mux, middleware)
+		if hooks, ok := inject.TryResolve[*inject.Hooks](c); ok {
+			hooks.Append(inject.Hook{Name: "httpserver", OnStart: server.Start, OnStop: server.Stop})
+		}
+		return server, nil
+	})
+}
+
+// Handle contributes a route, using http.ServeMux patterns.
+func Handle(container inject.Registrar, pattern string, handler http.Handler) error {
+	return inject.RegisterGroup[Route](container, RoutesGroup, func(*inject.Container) Route {
+		return Route{Pattern: pattern, Handler: handler}
+	})
+}
+
+// Use contributes middleware. Middleware contributed first is outermost.
+func Use(container inject.Registrar, middleware Middleware) error {
+	return inject.RegisterGroup[Middleware](container, MiddlewareGroup, func(*inject.Container) Middleware {
+		return middleware
+	})
+}
+
+// Server is an *http.Server with a managed lifecycle.
+type Server struct {
+	*http.Server
+	config   Config
+	mu       sync.Mutex
+	listener net.Listener
+	served   chan error
+}
+
+func newServer(config Config, mux *http.ServeMux, middleware []Middleware) *Server {
+	var handler http.Handler = mux
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	if config.ReadHeaderTimeout == 0 {
+		config.ReadHeaderTimeout = DefaultReadHeaderTimeout
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = DefaultIdleTimeout
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return &Server{
+		Server: &http.Server{
+			Addr:              config.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: config.ReadHeaderTimeout,
+			ReadTimeout:       config.ReadTimeout,
+			WriteTimeout:      config.WriteTimeout,
+			IdleTimeout:       config.IdleTimeout,
+		},
+		config: config,
+	}
+}
+
+// Start listens on the configured address and serves in the background.
+// Listening errors, such as the address being in use, are returned
+// directly.
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		return errors.New("server already started")
+	}
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Addr, err)
+	}
+	served := make(chan error, 1)
+	s.listener, s.served = listener, served
+
+	go func() {
+		var err error
+		if s.config.TLSCertFile != "" && s.config.TLSKeyFile != "" {
+			err = s.ServeTLS(listener, s.config.TLSCertFile, s.config.TLSKeyFile)
+		} else {
+			err = s.Serve(listener)
+		}
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		served <- err
+	}()
+	return nil
+}
+
+// ListenAddr returns the address the server listens on, which is useful
+// with port 0. It is empty before Start.
+func (s *Server) ListenAddr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop shuts the server down gracefully, waiting for in-flight requests for
+// at most the configured ShutdownTimeout, and returns any error the server
+// stopped with. Stopping a server that is not running does nothing.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	served := s.served
+	s.served = nil
+	s.mu.Unlock()
+	if served == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.ShutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return <-served
+}
@@ -0,0 +1,140 @@
+package httpserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+func TestServerServesContributedRoutes(t *testing.T) {
+	container := inject.NewContainer()
+	if err := Register(container, Config{Addr: "127.0.0.1:0"}); err != nil {
+		t.Fatalf("Failed to register server: %v", err)
+	}
+
+	err := Handle(container, "GET /hello", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	if err != nil {
+		t.Fatalf("Failed to contribute route: %v", err)
+	}
+	for _, name := range []string{"outer", "inner"} {
+		err := Use(container, func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("X-Middleware", name)
+				next.ServeHTTP(w, r)
+			})
+		})
+		if err != nil {
+			t.Fatalf("Failed to contribute middleware: %v", err)
+		}
+	}
+
+	server, ok := inject.TryResolve[*Server](container)
+	if !ok {
+		t.Fatal("Failed to resolve server")
+	}
+	if server.ReadHeaderTimeout != DefaultReadHeaderTimeout {
+		t.Errorf("Expected the default read header timeout, got %s", server.ReadHeaderTimeout)
+	}
+
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	if err := server.Start(context.Background()); err == nil {
+		t.Error("Expected an error starting the server twice")
+	}
+
+	resp, err := http.Get("http://" + server.ListenAddr() + "/hello")
+	if err != nil {
+		t.Fatalf("Failed to call server: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hello" {
+		t.Errorf("Expected hello, got %q", body)
+	}
+	if got := resp.Header.Values("X-Middleware"); len(got) != 2 || got[0] != "outer" || got[1] != "inner" {
+		t.Errorf("Expected middleware in contribution order, got %v", got)
+	}
+
+	if err := server.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop server: %v", err)
+	}
+	if err := server.Stop(context.Background()); err != nil {
+		t.Errorf("Expected stopping a stopped server to do nothing, got %v", err)
+	}
+}
+
+func TestStartReportsListenErrors(t *testing.T) {
+	container := inject.NewContainer()
+	if err := Register(container, Config{Addr: "127.0.0.1:-1"}); err != nil {
+		t.Fatalf("Failed to register server: %v", err)
+	}
+
+	server, ok := inject.TryResolve[*Server](container)
+	if !ok {
+		t.Fatal("Failed to resolve server")
+	}
+	if err := server.Start(context.Background()); err == nil {
+		t.Error("Expected an error listening on an invalid address")
+	}
+}
+
+func TestServerStartsAndStopsWithApp(t *testing.T) {
+	container := inject.NewContainer()
+	app, err := inject.NewApp(container)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	if err := Register(container, Config{Addr: "127.0.0.1:0"}); err != nil {
+		t.Fatalf("Failed to register server: %v", err)
+	}
+	err = Handle(container, "GET /ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "pong")
+	}))
+	if err != nil {
+		t.Fatalf("Failed to contribute route: %v", err)
+	}
+	err = inject.RegisterRoute(container, http.MethodGet, "/version", func() http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "v1")
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register route: %v", err)
+	}
+
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start app: %v", err)
+	}
+	server := inject.MustResolve[*Server](container)
+	resp, err := http.Get("http://" + server.ListenAddr() + "/ping")
+	if err != nil {
+		t.Fatalf("Failed to call server: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "pong" {
+		t.Errorf("Expected pong, got %q", body)
+	}
+	resp, err = http.Get("http://" + server.ListenAddr() + "/version")
+	if err != nil {
+		t.Fatalf("Failed to call server: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "v1" {
+		t.Errorf("Expected the route registered with inject.RegisterRoute, got %q", body)
+	}
+
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop app: %v", err)
+	}
+	if _, err := http.Get("http://" + server.ListenAddr() + "/ping"); err == nil {
+		t.Error("Expected the server to stop with the app")
+	}
+}
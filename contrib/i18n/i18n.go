@@ -0,0 +1,165 @@
+// Package i18n loads message catalogs and registers them with a go-inject
+// container. Scoped services take a Translator for the request's locale,
+// which Middleware fills in from the Accept-Language header.
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+// Translator translates message keys for one locale.
+type Translator interface {
+	Locale() string
+	// Translate returns the message for key formatted with args as
+	// fmt.Sprintf does, or key itself if no catalog has it.
+	Translate(key string, args ...any) string
+}
+
+// Catalogs holds the messages of every locale by key.
+type Catalogs struct {
+	fallback string
+	messages map[string]map[string]string
+}
+
+// NewCatalogs returns catalogs that fall back to the fallback locale for
+// messages missing from the requested one.
+func NewCatalogs(fallback string, messages map[string]map[string]string) *Catalogs {
+	normalized := make(map[string]map[string]string, len(messages))
+	for locale, catalog := range messages {
+		normalized[normalize(locale)] = catalog
+	}
+	return &Catalogs{fallback: normalize(fallback), messages: normalized}
+}
+
+// Load reads one JSON catalog per locale from fsys, named after the locale,
+// such as "en.json" or "pt-BR.json", each a flat object of messages by key.
+func Load(fsys fs.FS, dir string, fallback string) (*Catalogs, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("failed to parse catalog %s: %w", file, err)
+		}
+		messages[strings.TrimSuffix(path.Base(file), ".json")] = catalog
+	}
+	catalogs := NewCatalogs(fallback, messages)
+	if _, ok := catalogs.messages[catalogs.fallback]; !ok {
+		return nil, fmt.Errorf("no catalog for fallback locale %s in %s", fallback, dir)
+	}
+	return catalogs, nil
+}
+
+// Register registers catalogs with the container, and a Scoped Translator
+// for the locale of each scope's context.
+func Register(container inject.Registrar, catalogs *Catalogs) error {
+	if err := inject.RegisterValue(container, catalogs); err != nil {
+		return err
+	}
+	return container.Register((*Translator)(nil), func(ctx context.Context, catalogs *Catalogs) Translator {
+		return catalogs.ForContext(ctx)
+	}, inject.Scoped)
+}
+
+// For returns a translator for locale. A regional locale such as "en-GB"
+// falls back to its language, "en", and then to the fallback locale.
+func (c *Catalogs) For(locale string) Translator {
+	locale = normalize(locale)
+	t := &translator{}
+	for _, candidate := range []string{locale, baseLanguage(locale), c.fallback} {
+		if catalog, ok := c.messages[candidate]; ok {
+			if t.locale == "" {
+				t.locale = candidate
+			}
+			t.chain = append(t.chain, catalog)
+		}
+	}
+	return t
+}
+
+// ForContext returns a translator for the locale stored in ctx, or for the
+// fallback locale.
+func (c *Catalogs) ForContext(ctx context.Context) Translator {
+	return c.For(LocaleFromContext(ctx))
+}
+
+type translator struct {
+	locale string
+	chain  []map[string]string
+}
+
+func (t *translator) Locale() string {
+	return t.locale
+}
+
+func (t *translator) Translate(key string, args ...any) string {
+	for _, catalog := range t.chain {
+		if message, ok := catalog[key]; ok {
+			if len(args) == 0 {
+				return message
+			}
+			return fmt.Sprintf(message, args...)
+		}
+	}
+	return key
+}
+
+type localeKey struct{}
+
+// WithLocale stores locale in ctx for ForContext and scoped Translators.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored with WithLocale, or "" if
+// there is none.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Middleware stores the preferred locale of the Accept-Language header in
+// the request context. Quality values are ignored; the first listed
+// language wins.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Accept-Language")
+		if first, _, _ := strings.Cut(header, ","); first != "" {
+			locale, _, _ := strings.Cut(first, ";")
+			if locale = strings.TrimSpace(locale); locale != "" && locale != "*" {
+				r = r.WithContext(WithLocale(r.Context(), locale))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// normalize makes locales comparable: "en_us" and "EN-US" become "en-US".
+func normalize(locale string) string {
+	language, region, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if !found {
+		return strings.ToLower(language)
+	}
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+func baseLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+var catalogFiles = fstest.MapFS{
+	"locales/en.json":    {Data: []byte(`{"greeting": "Hello, %s", "farewell": "Goodbye"}`)},
+	"locales/pt.json":    {Data: []byte(`{"greeting": "Olá, %s", "farewell": "Adeus"}`)},
+	"locales/pt-BR.json": {Data: []byte(`{"farewell": "Tchau"}`)},
+}
+
+type greeter struct {
+	catalogs *Catalogs
+}
+
+func (g *greeter) Greet(ctx context.Context, name string) string {
+	return g.catalogs.ForContext(ctx).Translate("greeting", name)
+}
+
+func TestTranslatorPerRequest(t *testing.T) {
+	catalogs, err := Load(catalogFiles, "locales", "en")
+	if err != nil {
+		t.Fatalf("Failed to load catalogs: %v", err)
+	}
+	container := inject.NewContainer()
+	if err := Register(container, catalogs); err != nil {
+		t.Fatalf("Failed to register catalogs: %v", err)
+	}
+	err = container.RegisterSingleton((*greeter)(nil), func(catalogs *Catalogs) *greeter {
+		return &greeter{catalogs: catalogs}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register greeter: %v", err)
+	}
+
+	resolved, err := container.Resolve((*greeter)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve greeter: %v", err)
+	}
+	g := resolved.(*greeter)
+
+	var greeting string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		greeting = g.Greet(r.Context(), "Ana")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "pt-br;q=0.9, en;q=0.8")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if greeting != "Olá, Ana" {
+		t.Errorf("Expected the Portuguese greeting, got %q", greeting)
+	}
+}
+
+func TestTranslatorFallbacks(t *testing.T) {
+	catalogs, err := Load(catalogFiles, "locales", "en")
+	if err != nil {
+		t.Fatalf("Failed to load catalogs: %v", err)
+	}
+
+	tests := []struct {
+		locale, key, want, wantLocale string
+	}{
+		{"pt_BR", "farewell", "Tchau", "pt-BR"},
+		{"pt-BR", "greeting", "Olá, %s", "pt-BR"},
+		{"pt-PT", "farewell", "Adeus", "pt"},
+		{"de", "farewell", "Goodbye", "en"},
+		{"", "unknown.key", "unknown.key", "en"},
+	}
+	for _, tt := range tests {
+		translator := catalogs.For(tt.locale)
+		if got := translator.Translate(tt.key); got != tt.want {
+			t.Errorf("For(%q).Translate(%q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+		if translator.Locale() != tt.wantLocale {
+			t.Errorf("For(%q).Locale() = %q, want %q", tt.locale, translator.Locale(), tt.wantLocale)
+		}
+	}
+}
+
+func TestLoadRequiresFallback(t *testing.T) {
+	if _, err := Load(catalogFiles, "locales", "fr"); err == nil {
+		t.Error("Expected an error when the fallback catalog is missing")
+	}
+}
+
+func TestScopedTranslator(t *testing.T) {
+	catalogs, err := Load(catalogFiles, "locales", "en")
+	if err != nil {
+		t.Fatalf("Failed to load catalogs: %v", err)
+	}
+	container := inject.NewContainer()
+	if err := Register(container, catalogs); err != nil {
+		t.Fatalf("Failed to register catalogs: %v", err)
+	}
+
+	var farewell string
+	handler := Middleware(inject.HTTPMiddleware(container)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, _ := inject.ScopeFromContext(r.Context())
+		farewell = inject.MustResolve[Translator](scope).Translate("farewell")
+	})))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "pt-BR")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if farewell != "Tchau" {
+		t.Errorf("Expected the translator for the scope's locale, got %q", farewell)
+	}
+}
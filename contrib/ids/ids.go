@@ -0,0 +1,145 @@
+// Package ids registers ID generation and randomness abstractions with a
+// go-inject container. Tests register seeded implementations instead, so
+// the IDs handed out by services are reproducible.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	mathrand "math/rand/v2"
+	"sync"
+	"time"
+
+	inject "github.com/go-inject/go-inject"
+	"github.com/go-inject/go-inject/contrib/clock"
+)
+
+// RandSource is a source of random numbers. It matches math/rand/v2's
+// Source, so any of its sources can be used.
+type RandSource interface {
+	Uint64() uint64
+}
+
+type IDGenerator interface {
+	NewID() string
+}
+
+// Register registers a cryptographically random RandSource and an
+// IDGenerator producing version 4 UUIDs from it.
+func Register(container inject.Registrar) error {
+	if err := inject.RegisterValue[RandSource](container, CryptoSource()); err != nil {
+		return err
+	}
+	return inject.RegisterSingletonType[IDGenerator](container, func(c *inject.Container) IDGenerator {
+		return NewUUIDGenerator(inject.MustResolve[RandSource](c))
+	})
+}
+
+// RegisterULID registers an IDGenerator producing ULIDs, which sort by
+// creation time. It depends on the RandSource and on a clock.Clock.
+func RegisterULID(container inject.Registrar) error {
+	return inject.RegisterSingletonType[IDGenerator](container, func(c *inject.Container) IDGenerator {
+		return NewULIDGenerator(inject.MustResolve[clock.Clock](c), inject.MustResolve[RandSource](c))
+	})
+}
+
+// RegisterSeeded replaces the RandSource with a deterministic one, so every
+// run with the same seed produces the same IDs.
+func RegisterSeeded(container inject.Registrar, seed uint64) error {
+	return inject.RegisterValue[RandSource](container, Seeded(seed))
+}
+
+// CryptoSource returns a RandSource backed by crypto/rand.
+func CryptoSource() RandSource {
+	return cryptoSource{}
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Seeded returns a deterministic RandSource that is safe for concurrent use.
+func Seeded(seed uint64) RandSource {
+	return &seededSource{rand: mathrand.NewPCG(seed, seed)}
+}
+
+type seededSource struct {
+	mu   sync.Mutex
+	rand *mathrand.PCG
+}
+
+func (s *seededSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Uint64()
+}
+
+func NewUUIDGenerator(source RandSource) IDGenerator {
+	return uuidGenerator{source: source}
+}
+
+type uuidGenerator struct {
+	source RandSource
+}
+
+func (g uuidGenerator) NewID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], g.source.Uint64())
+	binary.BigEndian.PutUint64(b[8:], g.source.Uint64())
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+func NewULIDGenerator(clock clock.Clock, source RandSource) IDGenerator {
+	return ulidGenerator{clock: clock, source: source}
+}
+
+type ulidGenerator struct {
+	clock  clock.Clock
+	source RandSource
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewID encodes a 48-bit millisecond timestamp followed by 80 random bits
+// as 26 Crockford base32 characters.
+func (g ulidGenerator) NewID() string {
+	ms := uint64(g.clock.Now().UnixNano() / int64(time.Millisecond))
+	hi, lo := g.source.Uint64(), g.source.Uint64()
+
+	var b [16]byte
+	b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	binary.BigEndian.PutUint16(b[6:8], uint16(hi))
+	binary.BigEndian.PutUint64(b[8:], lo)
+
+	// 128 bits in 26 characters of 5 bits, with two leading zero bits
+	var s [26]byte
+	acc, bits, pos := uint32(0), uint(2), 0
+	for _, v := range b {
+		acc = acc<<8 | uint32(v)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			s[pos] = crockford[(acc>>bits)&0x1f]
+			pos++
+		}
+	}
+	return string(s[:])
+}
@@ -0,0 +1,70 @@
+package ids
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	inject "github.com/go-inject/go-inject"
+	"github.com/go-inject/go-inject/contrib/clock"
+)
+
+func newSeededContainer(t *testing.T, seed uint64) *inject.Container {
+	container := inject.NewContainer()
+	if err := Register(container); err != nil {
+		t.Fatalf("Failed to register ids: %v", err)
+	}
+	if err := RegisterSeeded(container, seed); err != nil {
+		t.Fatalf("Failed to register seeded source: %v", err)
+	}
+	return container
+}
+
+func TestSeededUUIDsAreReproducible(t *testing.T) {
+	first := inject.MustResolve[IDGenerator](newSeededContainer(t, 42))
+	second := inject.MustResolve[IDGenerator](newSeededContainer(t, 42))
+
+	for i := 0; i < 3; i++ {
+		a, b := first.NewID(), second.NewID()
+		if a != b {
+			t.Fatalf("Expected the same IDs for the same seed, got %s and %s", a, b)
+		}
+	}
+
+	other := inject.MustResolve[IDGenerator](newSeededContainer(t, 7))
+	if other.NewID() == first.NewID() {
+		t.Error("Expected different seeds to produce different IDs")
+	}
+}
+
+func TestUUIDFormat(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	generator := NewUUIDGenerator(CryptoSource())
+	for i := 0; i < 10; i++ {
+		if id := generator.NewID(); !pattern.MatchString(id) {
+			t.Errorf("Expected a version 4 UUID, got %s", id)
+		}
+	}
+}
+
+func TestULID(t *testing.T) {
+	container := newSeededContainer(t, 1)
+	fake, err := clock.RegisterFake(container, time.UnixMilli(1469918176385))
+	if err != nil {
+		t.Fatalf("Failed to register fake clock: %v", err)
+	}
+	if err := RegisterULID(container); err != nil {
+		t.Fatalf("Failed to register ULID generator: %v", err)
+	}
+
+	generator := inject.MustResolve[IDGenerator](container)
+	first := generator.NewID()
+	if len(first) != 26 || first[:10] != "01ARYZ6S41" {
+		t.Errorf("Expected the ULID to start with the encoded timestamp, got %s", first)
+	}
+
+	fake.Advance(time.Millisecond)
+	if second := generator.NewID(); second <= first {
+		t.Errorf("Expected later ULIDs to sort after earlier ones, got %s then %s", first, second)
+	}
+}
@@ -0,0 +1,95 @@
+// Package logging registers a Logger with a go-inject container. The logger
+// is backed by any slog.Handler, which is how other logging libraries are
+// plugged in: zap through zapslog, zerolog through its slog handler, and so
+// on.
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+// Logger is the logging interface services depend on.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+	// With returns a child logger that adds args to every record.
+	With(args ...any) Logger
+	// ForContext returns a child logger carrying the request metadata
+	// attached to ctx with WithAttrs, and the tenant if there is one.
+	ForContext(ctx context.Context) Logger
+}
+
+// RequestLogger is the Logger of a scope. It carries the metadata of the
+// scope's context, as ForContext does, so services of a request log with
+// its request ID and tenant.
+type RequestLogger interface {
+	Logger
+}
+
+// Register registers a Logger writing to handler, and a Scoped
+// RequestLogger derived from it for each scope.
+func Register(container inject.Registrar, handler slog.Handler) error {
+	if err := inject.RegisterValue(container, New(handler)); err != nil {
+		return err
+	}
+	return container.Register((*RequestLogger)(nil), func(ctx context.Context, logger Logger) RequestLogger {
+		return logger.ForContext(ctx)
+	}, inject.Scoped)
+}
+
+func New(handler slog.Handler) Logger {
+	return &logger{slog: slog.New(handler)}
+}
+
+type attrsKey struct{}
+
+// WithAttrs attaches request metadata, as slog key-value pairs, to ctx.
+// Loggers obtained with ForContext add it to every record.
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]any)
+	attrs := append(existing[:len(existing):len(existing)], args...)
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+type logger struct {
+	slog *slog.Logger
+}
+
+func (l *logger) Debug(msg string, args ...any) {
+	l.slog.Debug(msg, args...)
+}
+
+func (l *logger) Info(msg string, args ...any) {
+	l.slog.Info(msg, args...)
+}
+
+func (l *logger) Warn(msg string, args ...any) {
+	l.slog.Warn(msg, args...)
+}
+
+func (l *logger) Error(msg string, args ...any) {
+	l.slog.Error(msg, args...)
+}
+
+func (l *logger) With(args ...any) Logger {
+	return &logger{slog: l.slog.With(args...)}
+}
+
+func (l *logger) ForContext(ctx context.Context) Logger {
+	var args []any
+	if tenant, _, ok := inject.TenantFromContext(ctx); ok {
+		args = append(args, "tenant", tenant)
+	}
+	if attrs, ok := ctx.Value(attrsKey{}).([]any); ok {
+		args = append(args, attrs...)
+	}
+	if len(args) == 0 {
+		return l
+	}
+	return l.With(args...)
+}
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	inject "github.com/go-inject/go-inject"
+)
+
+func TestRegister(t *testing.T) {
+	var buf bytes.Buffer
+	container := inject.NewContainer()
+	if err := Register(container, slog.NewTextHandler(&buf, nil)); err != nil {
+		t.Fatalf("Failed to register logger: %v", err)
+	}
+
+	logger := inject.MustResolve[Logger](container)
+	logger.With("component", "billing").Info("charged", "amount", 42)
+
+	if out := buf.String(); !strings.Contains(out, "component=billing") || !strings.Contains(out, "amount=42") {
+		t.Errorf("Expected the child logger's attributes, got %q", out)
+	}
+}
+
+func TestForContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(slog.NewTextHandler(&buf, nil))
+
+	ctx := inject.WithTenant(context.Background(), "acme", inject.NewContainer())
+	ctx = WithAttrs(ctx, "request_id", "r-1")
+	ctx = WithAttrs(ctx, "user", "u-7")
+	logger.ForContext(ctx).Warn("slow request")
+
+	out := buf.String()
+	for _, want := range []string{"tenant=acme", "request_id=r-1", "user=u-7", "level=WARN"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %s in %q", want, out)
+		}
+	}
+
+	if logger.ForContext(context.Background()) != logger {
+		t.Error("Expected a context without metadata to reuse the logger")
+	}
+}
+
+func TestRequestLoggerCarriesScopeMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	container := inject.NewContainer()
+	if err := Register(container, slog.NewTextHandler(&buf, nil)); err != nil {
+		t.Fatalf("Failed to register logger: %v", err)
+	}
+
+	scope := container.NewScopeWithContext(WithAttrs(context.Background(), "request_id", "r-9"))
+	defer scope.Close()
+	logger := inject.MustResolve[RequestLogger](scope)
+	logger.Info("handled")
+
+	if out := buf.String(); !strings.Contains(out, "request_id=r-9") {
+		t.Errorf("Expected the scope's request metadata, got %q", out)
+	}
+	if _, ok := inject.TryResolve[RequestLogger](container); ok {
+		t.Error("Expected the request logger to need a scope")
+	}
+}
@@ -0,0 +1,113 @@
+package inject
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+type debugService struct {
+	Type         string
+	Description  string
+	Lifecycle    string
+	Status       string
+	Created      uint64
+	Failures     uint64
+	LastError    string
+	Dependencies []string
+}
+
+type debugPage struct {
+	Services      []debugService
+	Registrations int
+	Singletons    int
+	Instantiated  int
+}
+
+var debugTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>go-inject container</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+code { font-size: 0.95em; }
+</style>
+</head>
+<body>
+<h1>go-inject container</h1>
+<p>{{.Registrations}} registrations, {{.Singletons}} singletons ({{.Instantiated}} instantiated)</p>
+<table>
+<tr><th>Service</th><th>Description</th><th>Lifecycle</th><th>Status</th><th>Created</th><th>Failures</th><th>Dependencies</th></tr>
+{{range .Services}}<tr>
+<td><code>{{.Type}}</code></td>
+<td>{{if .Description}}{{.Description}}{{else}}-{{end}}</td>
+<td>{{.Lifecycle}}</td>
+<td>{{.Status}}</td>
+<td>{{.Created}}</td>
+<td>{{.Failures}}{{if .LastError}}<br><small>{{.LastError}}</small>{{end}}</td>
+<td>{{range .Dependencies}}<code>{{.}}</code><br>{{else}}-{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func DebugHandler(container *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugTemplate.Execute(w, container.debugPage()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func (c *Container) debugPage() debugPage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	page := debugPage{Registrations: len(c.services)}
+	for _, descriptor := range c.sortedDescriptors() {
+		service := debugService{
+			Type:        descriptor.displayName(),
+			Description: descriptor.Description,
+			Lifecycle:   descriptor.Lifecycle.String(),
+			Status:      "-",
+		}
+
+		stats := descriptor.snapshotStats()
+		service.Created = stats.Created
+		service.Failures = stats.Failures
+		if stats.LastError != nil {
+			service.LastError = stats.LastError.Error()
+		}
+
+		if descriptor.Lifecycle == Singleton {
+			page.Singletons++
+			service.Status = "not created"
+			descriptor.mu.RLock()
+			if descriptor.instance != nil {
+				service.Status = "created"
+				page.Instantiated++
+			} else if descriptor.inflight != nil {
+				service.Status = "creating"
+			}
+			descriptor.mu.RUnlock()
+		}
+
+		if descriptor.pool != nil {
+			idle, inUse := descriptor.pool.counts()
+			service.Status = fmt.Sprintf("%d idle, %d in use", idle, inUse)
+		}
+
+		for _, dep := range c.dependenciesOf(descriptor) {
+			service.Dependencies = append(service.Dependencies, dep.String())
+		}
+
+		page.Services = append(page.Services, service)
+	}
+	return page
+}
@@ -0,0 +1,92 @@
+package inject
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandler(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "debug"}
+	}, WithDescription("shared <test> dependency"))
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*TestInterface)(nil)); err != nil {
+		t.Fatalf("Failed to resolve interface: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	DebugHandler(container).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/inject", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("Expected HTML content type, got '%s'", recorder.Header().Get("Content-Type"))
+	}
+
+	body := recorder.Body.String()
+	for _, expected := range []string{
+		"2 registrations, 1 singletons (1 instantiated)",
+		"inject.TestService",
+		"inject.TestInterface",
+		"Transient",
+		"Singleton",
+		"created",
+		"shared &lt;test&gt; dependency",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Debug page should contain '%s'", expected)
+		}
+	}
+}
+
+func TestDebugHandlerExpandsDependencies(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(c *Container, all []TestInterface) *TestService {
+		return &TestService{dependency: all[0]}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	for _, service := range container.debugPage().Services {
+		if service.Type != "inject.TestService" {
+			continue
+		}
+		if len(service.Dependencies) != 1 || service.Dependencies[0] != "inject.TestInterface" {
+			t.Errorf("Expected the dependencies the resolver sees, got %v", service.Dependencies)
+		}
+		return
+	}
+	t.Error("Debug page should list inject.TestService")
+}
+
+func TestLifecycleString(t *testing.T) {
+	if Singleton.String() != "Singleton" || Transient.String() != "Transient" {
+		t.Error("Lifecycle should have readable names")
+	}
+	if Lifecycle(42).String() != "Lifecycle(42)" {
+		t.Errorf("Unknown lifecycle should be formatted numerically, got '%s'", Lifecycle(42).String())
+	}
+}
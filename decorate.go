@@ -0,0 +1,53 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// Decorate wraps the service registered as T, for example to put a caching
+// layer around a repository without re-registering it. Decorators run each
+// time the service is built, so they follow its lifecycle, and they stack in
+// the order they were added: the last one added is the outermost. A cached
+// singleton is dropped and disposed like Close does, so the next
+// resolution builds a decorated one.
+func Decorate[T any](container *Container, decorator func(inner T, c *Container) T) error {
+	serviceType := reflect.TypeFor[T]()
+	if decorator == nil {
+		return newError(ErrCodeInvalidFactory, serviceType, "decorator must not be nil")
+	}
+
+	container.mu.Lock()
+	descriptor, exists := container.services[serviceKey{serviceType: serviceType}]
+	if !exists {
+		defer container.mu.Unlock()
+		return container.notRegisteredError(serviceType)
+	}
+	descriptor.decorators = append(descriptor.decorators, func(c *Container, inner interface{}) interface{} {
+		value, _ := inner.(T)
+		return decorator(value, c)
+	})
+	var instance interface{}
+	var cleanup func()
+	if descriptor.Lifecycle == Singleton {
+		instance, cleanup, _ = descriptor.dropInstance()
+	}
+	container.mu.Unlock()
+
+	return closeEvicted(serviceType, instance, cleanup)
+}
+
+// decorate applies the decorators of descriptor to a freshly built instance.
+func (c *Container) decorate(descriptor *ServiceDescriptor, instance interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = newError(ErrCodeFactoryPanic, descriptor.ServiceType, "decorator for %s panicked: %v", descriptor.ServiceType.String(), r)
+			c.factoryFailed(descriptor, err)
+		}
+	}()
+
+	for _, decorator := range descriptor.decorators {
+		instance = decorator(c, instance)
+	}
+	return instance, nil
+}
@@ -0,0 +1,107 @@
+package inject
+
+import (
+	"testing"
+)
+
+type prefixDecorator struct {
+	prefix string
+	inner  TestInterface
+}
+
+func (d *prefixDecorator) GetValue() string {
+	return d.prefix + d.inner.GetValue()
+}
+
+func TestDecorate(t *testing.T) {
+	container := NewContainer()
+
+	builds := 0
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		builds++
+		return &TestImplementation{value: "repo"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	MustResolve[TestInterface](container)
+
+	for _, prefix := range []string{"cache:", "log:"} {
+		err := Decorate[TestInterface](container, func(inner TestInterface, c *Container) TestInterface {
+			return &prefixDecorator{prefix: prefix, inner: inner}
+		})
+		if err != nil {
+			t.Fatalf("Failed to decorate service: %v", err)
+		}
+	}
+
+	first := MustResolve[TestInterface](container)
+	if first.GetValue() != "log:cache:repo" {
+		t.Errorf("Expected decorators to stack with the last outermost, got '%s'", first.GetValue())
+	}
+	if MustResolve[TestInterface](container) != first || builds != 2 {
+		t.Errorf("Expected the decorated singleton to be built once more and cached, got %d builds", builds)
+	}
+
+	err = Decorate[*TestService](container, func(inner *TestService, c *Container) *TestService {
+		return inner
+	})
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected %s when decorating an unregistered service, got %v", ErrCodeNotRegistered, err)
+	}
+}
+
+func TestDecorateTransient(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "a"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	decorations := 0
+	err = Decorate[*TestImplementation](container, func(inner *TestImplementation, c *Container) *TestImplementation {
+		decorations++
+		inner.value += "!"
+		return inner
+	})
+	if err != nil {
+		t.Fatalf("Failed to decorate service: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if value := MustResolve[*TestImplementation](container).GetValue(); value != "a!" {
+			t.Errorf("Expected 'a!', got '%s'", value)
+		}
+	}
+	if decorations != 2 {
+		t.Errorf("Expected each transient to be decorated, got %d decorations", decorations)
+	}
+}
+
+func TestDecorateClosesUndecoratedSingleton(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	err := container.RegisterSingleton((**orderedCloser)(nil), func() *orderedCloser {
+		return &orderedCloser{name: "plain", closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	MustResolve[*orderedCloser](container)
+
+	err = Decorate[*orderedCloser](container, func(inner *orderedCloser, c *Container) *orderedCloser {
+		return &orderedCloser{name: "decorated", closed: inner.closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to decorate service: %v", err)
+	}
+	if len(closed) != 1 || closed[0] != "plain" {
+		t.Errorf("Expected the undecorated singleton to be closed, got %v", closed)
+	}
+	if name := MustResolve[*orderedCloser](container).name; name != "decorated" {
+		t.Errorf("Expected the decorated singleton, got %s", name)
+	}
+}
@@ -0,0 +1,147 @@
+package inject
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type ServiceFilter func(descriptor *ServiceDescriptor) bool
+
+func ByLifecycle(lifecycle Lifecycle) ServiceFilter {
+	return func(descriptor *ServiceDescriptor) bool {
+		return descriptor.Lifecycle == lifecycle
+	}
+}
+
+func ByPackagePrefix(prefix string) ServiceFilter {
+	return func(descriptor *ServiceDescriptor) bool {
+		return strings.HasPrefix(typePackage(descriptor.ServiceType), prefix)
+	}
+}
+
+func ByTag(tag string) ServiceFilter {
+	return func(descriptor *ServiceDescriptor) bool {
+		return slices.Contains(descriptor.Tags, tag)
+	}
+}
+
+// Descriptors returns a copy of the registrations matching all filters, in
+// the order of GetServiceTypes. Changing the copies does not affect the
+// container.
+func (c *Container) Descriptors(filters ...ServiceFilter) []*ServiceDescriptor {
+	descriptors := c.descriptors(filters)
+	for i, descriptor := range descriptors {
+		descriptors[i] = descriptor.snapshot()
+	}
+	return descriptors
+}
+
+// descriptors returns the registrations matching all filters themselves.
+func (c *Container) descriptors(filters []ServiceFilter) []*ServiceDescriptor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var descriptors []*ServiceDescriptor
+	for _, descriptor := range c.sortedDescriptors() {
+		if matchesFilters(descriptor, filters) {
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+	return descriptors
+}
+
+// Descriptor returns a copy of the registration of serviceType.
+func (c *Container) Descriptor(serviceType interface{}) (*ServiceDescriptor, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	descriptor, exists := c.services[serviceKey{serviceType: serviceTypeOf(serviceType)}]
+	if !exists {
+		return nil, false
+	}
+	return descriptor.snapshot(), true
+}
+
+// snapshot copies the exported fields of the registration.
+func (d *ServiceDescriptor) snapshot() *ServiceDescriptor {
+	return &ServiceDescriptor{
+		ServiceType: d.ServiceType,
+		Name:        d.Name,
+		Group:       d.Group,
+		Factory:     d.Factory,
+		Lifecycle:   d.Lifecycle,
+		Description: d.Description,
+		Metadata:    maps.Clone(d.Metadata),
+		Tags:        slices.Clone(d.Tags),
+	}
+}
+
+func matchesFilters(descriptor *ServiceDescriptor, filters []ServiceFilter) bool {
+	for _, filter := range filters {
+		if !filter(descriptor) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedDescriptors orders registrations by package path and then by type
+// name so that anything built from them is reproducible. Callers must hold
+// c.mu.
+func (c *Container) sortedDescriptors() []*ServiceDescriptor {
+	descriptors := make([]*ServiceDescriptor, 0, len(c.services))
+	for _, descriptor := range c.services {
+		descriptors = append(descriptors, descriptor)
+	}
+	sortDescriptors(descriptors)
+	return descriptors
+}
+
+// sortDescriptors orders descriptors by type, then name, group and
+// sequence number, so registrations sharing a type keep a stable order.
+func sortDescriptors(descriptors []*ServiceDescriptor) {
+	sort.SliceStable(descriptors, func(i, j int) bool {
+		a, b := descriptors[i], descriptors[j]
+		switch {
+		case a.ServiceType != b.ServiceType:
+			return lessType(a.ServiceType, b.ServiceType)
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		case a.Group != b.Group:
+			return a.Group < b.Group
+		}
+		return a.seq < b.seq
+	})
+}
+
+func lessType(a, b reflect.Type) bool {
+	pkgA, pkgB := typePackage(a), typePackage(b)
+	if pkgA != pkgB {
+		return pkgA < pkgB
+	}
+	return a.String() < b.String()
+}
+
+// typePackage returns the import path of the named type underneath any
+// pointer, slice, array, map or channel wrappers.
+func typePackage(t reflect.Type) string {
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			t = t.Elem()
+		default:
+			return ""
+		}
+	}
+	return t.PkgPath()
+}
+
+func ByMetadata(key string, value interface{}) ServiceFilter {
+	return func(descriptor *ServiceDescriptor) bool {
+		v, ok := descriptor.Metadata[key]
+		return ok && reflect.DeepEqual(v, value)
+	}
+}
@@ -0,0 +1,142 @@
+package inject
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func registerEnumerationServices(t *testing.T, container *Container) {
+	t.Helper()
+
+	err := RegisterTransientType[*TestService](container, func(c *Container) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = RegisterValue[http.Handler](container, http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	err = RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	err = RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+}
+
+func TestGetServiceTypesIsSorted(t *testing.T) {
+	container := NewContainer()
+	registerEnumerationServices(t, container)
+
+	expected := []string{
+		"*inject.TestRepository",
+		"*inject.TestService",
+		"inject.TestInterface",
+		"http.Handler",
+	}
+
+	for i := 0; i < 5; i++ {
+		types := container.GetServiceTypes()
+		if len(types) != len(expected) {
+			t.Fatalf("Expected %d types, got %d", len(expected), len(types))
+		}
+		for j, serviceType := range types {
+			if serviceType.String() != expected[j] {
+				t.Fatalf("Expected %s at position %d, got %s", expected[j], j, serviceType.String())
+			}
+		}
+	}
+}
+
+func TestDescriptorsFilters(t *testing.T) {
+	container := NewContainer()
+	registerEnumerationServices(t, container)
+
+	singletons := container.Descriptors(ByLifecycle(Singleton))
+	if len(singletons) != 3 {
+		t.Errorf("Expected 3 singletons, got %d", len(singletons))
+	}
+
+	local := container.Descriptors(ByPackagePrefix("github.com/go-inject/"))
+	if len(local) != 3 {
+		t.Errorf("Expected 3 services from this package, got %d", len(local))
+	}
+
+	both := container.Descriptors(ByLifecycle(Transient), ByPackagePrefix("github.com/go-inject/"))
+	if len(both) != 1 || both[0].ServiceType != reflect.TypeOf((*TestService)(nil)) {
+		t.Error("Filters should be combined")
+	}
+
+	if len(container.Descriptors(ByPackagePrefix("net/"))) != 1 {
+		t.Error("Package prefix should match standard library types")
+	}
+}
+
+func TestDescriptorsAreCopies(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	}, WithMetadata("owner", "payments-team"), WithTags("critical"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	descriptor, _ := container.Descriptor((*TestInterface)(nil))
+	descriptor.Lifecycle = Transient
+	descriptor.Metadata["owner"] = "someone-else"
+	container.Descriptors()[0].Tags[0] = "ignored"
+
+	descriptor, _ = container.Descriptor((*TestInterface)(nil))
+	if descriptor.Lifecycle != Singleton || descriptor.Metadata["owner"] != "payments-team" || descriptor.Tags[0] != "critical" {
+		t.Errorf("Changing a descriptor should not affect the container, got %+v", descriptor)
+	}
+}
+
+func TestDescriptorsOrderSameType(t *testing.T) {
+	container := NewContainer()
+
+	for _, opts := range [][]RegistrationOption{
+		{WithDescription("first"), Append()},
+		{WithDescription("member"), WithGroup("checks")},
+		{WithDescription("second"), Append()},
+		{WithDescription("third"), Append()},
+	} {
+		err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+			return &TestImplementation{}
+		}, opts...)
+		if err != nil {
+			t.Fatalf("Failed to register service: %v", err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		var order []string
+		for _, descriptor := range container.Descriptors() {
+			order = append(order, descriptor.Description)
+		}
+		if strings.Join(order, ",") != "first,second,third,member" {
+			t.Fatalf("Expected registrations in a stable order, got %v", order)
+		}
+
+		container.mu.RLock()
+		descriptor, _ := container.descriptorByName("inject.TestInterface")
+		container.mu.RUnlock()
+		if descriptor.Description != "first" {
+			t.Fatalf("Expected the first ungrouped registration by name, got %q", descriptor.Description)
+		}
+	}
+}
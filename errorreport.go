@@ -0,0 +1,47 @@
+package inject
+
+import (
+	"reflect"
+)
+
+type ErrorReport struct {
+	ServiceType reflect.Type
+	Path        []reflect.Type
+	Err         error
+	Panic       bool
+}
+
+type ErrorReporter func(report ErrorReport)
+
+func (c *Container) SetErrorReporter(reporter ErrorReporter) {
+	if reporter == nil {
+		c.reporter.Store(nil)
+		return
+	}
+	c.reporter.Store(&reporter)
+}
+
+// report hands a failure to the error reporter, if any. The path is the
+// chain of services being built through this handle, ending with
+// serviceType.
+func (c *Container) report(serviceType reflect.Type, err error, panicking bool) {
+	reporter := c.reporter.Load()
+	if reporter == nil {
+		return
+	}
+
+	var path []reflect.Type
+	if c.frame != nil && !c.frame.done.Load() {
+		path = c.frame.types()
+	}
+	if serviceType != nil && (len(path) == 0 || path[len(path)-1] != serviceType) {
+		path = append(path, serviceType)
+	}
+
+	(*reporter)(ErrorReport{
+		ServiceType: serviceType,
+		Path:        path,
+		Err:         err,
+		Panic:       panicking,
+	})
+}
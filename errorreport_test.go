@@ -0,0 +1,96 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrorReporterOnFactoryError(t *testing.T) {
+	container := NewContainer()
+
+	var reports []ErrorReport
+	container.SetErrorReporter(func(report ErrorReport) {
+		reports = append(reports, report)
+	})
+
+	err := container.RegisterTransient((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		return nil, errors.New("dial failed")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = container.RegisterTransient((*TestService)(nil), func(impl TestImplementation) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*TestService)(nil)); err == nil {
+		t.Fatal("Expected resolution error")
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report for the failing factory, got %d", len(reports))
+	}
+
+	report := reports[0]
+	if report.Panic {
+		t.Error("Factory errors should not be reported as panics")
+	}
+	if report.Err == nil || report.Err.Error() != "dial failed" {
+		t.Errorf("Report should carry the factory error, got %v", report.Err)
+	}
+
+	expected := []reflect.Type{reflect.TypeOf(TestService{}), reflect.TypeOf(TestImplementation{})}
+	if !reflect.DeepEqual(report.Path, expected) {
+		t.Errorf("Expected path %v, got %v", expected, report.Path)
+	}
+}
+
+func TestErrorReporterBeforeMustResolvePanic(t *testing.T) {
+	container := NewContainer()
+
+	var reports []ErrorReport
+	container.SetErrorReporter(func(report ErrorReport) {
+		reports = append(reports, report)
+	})
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("MustResolve should panic when service is not registered")
+			}
+		}()
+		MustResolve[*TestImplementation](container)
+	}()
+
+	if len(reports) != 1 || !reports[0].Panic {
+		t.Fatalf("Expected one panic report, got %+v", reports)
+	}
+	if reports[0].ServiceType != reflect.TypeOf((*TestImplementation)(nil)) {
+		t.Errorf("Unexpected service type %v", reports[0].ServiceType)
+	}
+}
+
+func TestErrorReporterCanBeRemoved(t *testing.T) {
+	container := NewContainer()
+
+	called := false
+	container.SetErrorReporter(func(report ErrorReport) {
+		called = true
+	})
+	container.SetErrorReporter(nil)
+
+	TryResolve[*TestImplementation](container)
+	func() {
+		defer func() { recover() }()
+		MustResolve[*TestImplementation](container)
+	}()
+
+	if called {
+		t.Error("Removed reporter should not be called")
+	}
+}
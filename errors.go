@@ -0,0 +1,127 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type ErrorCode string
+
+const (
+	ErrCodeNotRegistered      ErrorCode = "NOT_REGISTERED"
+	ErrCodeInvalidFactory     ErrorCode = "INVALID_FACTORY"
+	ErrCodeTypeMismatch       ErrorCode = "TYPE_MISMATCH"
+	ErrCodeMissingDependency  ErrorCode = "MISSING_DEPENDENCY"
+	ErrCodeFactoryError       ErrorCode = "FACTORY_ERROR"
+	ErrCodeFactoryPanic       ErrorCode = "FACTORY_PANIC"
+	ErrCodeDepthExceeded      ErrorCode = "DEPTH_EXCEEDED"
+	ErrCodeCycle              ErrorCode = "CYCLE"
+	ErrCodeReflectionDisabled ErrorCode = "REFLECTION_DISABLED"
+	ErrCodeInvalidArgument    ErrorCode = "INVALID_ARGUMENT"
+	ErrCodeAccessDenied       ErrorCode = "ACCESS_DENIED"
+	ErrCodeLifetimeMismatch   ErrorCode = "LIFETIME_MISMATCH"
+	ErrCodeScopeClosed        ErrorCode = "SCOPE_CLOSED"
+	ErrCodeContainerClosed    ErrorCode = "CONTAINER_CLOSED"
+)
+
+// Error is the error type returned for every container failure. Dependency
+// failures are wrapped with plain context, so errors.As finds the Error that
+// describes the root cause.
+type Error struct {
+	Code        ErrorCode
+	ServiceType reflect.Type
+	Err         error
+	message     string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func ErrorCodeOf(err error) ErrorCode {
+	var injectErr *Error
+	if errors.As(err, &injectErr) {
+		return injectErr.Code
+	}
+	return ""
+}
+
+func newError(code ErrorCode, serviceType reflect.Type, format string, args ...interface{}) *Error {
+	err := fmt.Errorf(format, args...)
+	return &Error{
+		Code:        code,
+		ServiceType: serviceType,
+		Err:         errors.Unwrap(err),
+		message:     err.Error(),
+	}
+}
+
+func factoryError(serviceType reflect.Type, err error) *Error {
+	// Keep the code of errors the container itself raised about this service
+	if injectErr, ok := err.(*Error); ok && injectErr.ServiceType == serviceType {
+		return injectErr
+	}
+	// A factory passing on a failed lookup reports the root cause's code,
+	// as dependency failures do
+	code := ErrCodeFactoryError
+	var injectErr *Error
+	if errors.As(err, &injectErr) {
+		code = injectErr.Code
+	}
+	return &Error{
+		Code:        code,
+		ServiceType: serviceType,
+		Err:         err,
+		message:     err.Error(),
+	}
+}
+
+// ResolutionError reports a failure below the service that was requested.
+// Path runs from the requested service down to the one that failed, and Err
+// is that failure, so errors.As still finds its *Error.
+type ResolutionError struct {
+	Path []reflect.Type
+	Err  error
+}
+
+func (e *ResolutionError) Error() string {
+	names := make([]string, len(e.Path))
+	for i, serviceType := range e.Path {
+		names[i] = serviceType.String()
+	}
+	return fmt.Sprintf("failed to resolve dependency %s (%s): %v", names[len(names)-1], strings.Join(names, " -> "), e.Err)
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// resolutionError records the resolution path of a failure to resolve
+// serviceType from within a factory. Failures of top-level requests, and
+// those already carrying their path, are returned as they are.
+func (c *Container) resolutionError(serviceType reflect.Type, err error) error {
+	if err == nil || c.frame == nil || c.frame.done.Load() {
+		return err
+	}
+	var resolutionErr *ResolutionError
+	if errors.As(err, &resolutionErr) {
+		return err
+	}
+	return &ResolutionError{Path: append(c.frame.types(), serviceType), Err: err}
+}
+
+// dependencyError wraps the failure to resolve a dependency, unless it
+// already carries its resolution path.
+func dependencyError(serviceType reflect.Type, err error) error {
+	var resolutionErr *ResolutionError
+	if errors.As(err, &resolutionErr) {
+		return err
+	}
+	return fmt.Errorf("failed to resolve dependency %s: %w", serviceType.String(), err)
+}
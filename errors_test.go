@@ -0,0 +1,171 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorCodes(t *testing.T) {
+	container := NewContainer(WithMaxResolutionDepth(3))
+
+	_, err := container.Resolve((*TestImplementation)(nil))
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected %s, got %s", ErrCodeNotRegistered, ErrorCodeOf(err))
+	}
+
+	err = container.Register((*TestImplementation)(nil), "not a function", Transient)
+	if ErrorCodeOf(err) != ErrCodeInvalidFactory {
+		t.Errorf("Expected %s, got %s", ErrCodeInvalidFactory, ErrorCodeOf(err))
+	}
+
+	err = container.Register((*TestImplementation)(nil), func() string { return "" }, Transient)
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected %s, got %s", ErrCodeTypeMismatch, ErrorCodeOf(err))
+	}
+
+	err = container.RegisterTransient((*TestRecursiveService)(nil), func(next TestRecursiveService) *TestRecursiveService {
+		return &TestRecursiveService{next: &next}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	_, err = container.Resolve((*TestRecursiveService)(nil))
+	if ErrorCodeOf(err) != ErrCodeCycle {
+		t.Errorf("Expected %s, got %s", ErrCodeCycle, ErrorCodeOf(err))
+	}
+
+	if ErrorCodeOf(errors.New("plain")) != "" {
+		t.Error("Foreign errors should have no code")
+	}
+}
+
+func TestFactoryErrorCodeKeepsCause(t *testing.T) {
+	container := NewContainer()
+	cause := errors.New("connection refused")
+
+	err := container.RegisterTransient((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		return nil, cause
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = container.RegisterTransient((*TestService)(nil), func(impl TestImplementation) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestService)(nil))
+
+	var injectErr *Error
+	if !errors.As(err, &injectErr) {
+		t.Fatalf("Expected *Error in chain, got %v", err)
+	}
+	if injectErr.Code != ErrCodeFactoryError {
+		t.Errorf("Expected root cause code %s, got %s", ErrCodeFactoryError, injectErr.Code)
+	}
+	if injectErr.ServiceType != reflect.TypeOf(TestImplementation{}) {
+		t.Errorf("Error should name the failing service, got %v", injectErr.ServiceType)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Factory errors should unwrap to the original error")
+	}
+}
+
+func TestFactoryPanicIsRecovered(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestImplementation)(nil), func() *TestImplementation {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestImplementation)(nil))
+	if ErrorCodeOf(err) != ErrCodeFactoryPanic {
+		t.Fatalf("Expected %s, got %v", ErrCodeFactoryPanic, err)
+	}
+	if err.Error() != "factory for inject.TestImplementation panicked: boom" {
+		t.Errorf("Unexpected panic error message: %s", err.Error())
+	}
+
+	// The singleton lock must have been released by the failed attempt
+	_, err = container.Resolve((*TestImplementation)(nil))
+	if ErrorCodeOf(err) != ErrCodeFactoryPanic {
+		t.Errorf("Expected %s on retry, got %v", ErrCodeFactoryPanic, err)
+	}
+}
+
+func TestMissingDependencyCode(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if ErrorCodeOf(container.Validate()) != ErrCodeMissingDependency {
+		t.Errorf("Expected %s from Validate", ErrCodeMissingDependency)
+	}
+}
+
+func TestResolutionErrorPath(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((**TestRepository)(nil), func(c *Container) (*TestRepository, error) {
+		if _, err := c.Resolve((*TestInterface)(nil)); err != nil {
+			return nil, err
+		}
+		return &TestRepository{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = container.RegisterTransient((**TestService)(nil), func(repo *TestRepository) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((**TestImplementation)(nil), func(svc *TestService) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register implementation: %v", err)
+	}
+
+	_, err = container.Resolve((**TestImplementation)(nil))
+	var resolutionErr *ResolutionError
+	if !errors.As(err, &resolutionErr) {
+		t.Fatalf("Expected a ResolutionError, got %v", err)
+	}
+	expected := []reflect.Type{
+		reflect.TypeFor[*TestImplementation](),
+		reflect.TypeFor[*TestService](),
+		reflect.TypeFor[*TestRepository](),
+		reflect.TypeFor[TestInterface](),
+	}
+	if !reflect.DeepEqual(resolutionErr.Path, expected) {
+		t.Errorf("Expected path %v, got %v", expected, resolutionErr.Path)
+	}
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected the root cause's code %s, got %s", ErrCodeNotRegistered, ErrorCodeOf(err))
+	}
+	want := "failed to resolve dependency inject.TestInterface (*inject.TestImplementation -> *inject.TestService -> *inject.TestRepository -> inject.TestInterface): service of type inject.TestInterface not registered"
+	if !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Expected '%s', got '%s'", want, err.Error())
+	}
+
+	// Failures of the requested service itself need no path
+	_, err = container.Resolve((*TestInterface)(nil))
+	if errors.As(err, &resolutionErr) {
+		t.Errorf("Expected a plain error for a top-level failure, got %v", err)
+	}
+}
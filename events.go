@@ -0,0 +1,223 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type EventHandler interface {
+	Handle(ctx context.Context, event interface{}) error
+}
+
+type EventHandlerFunc func(ctx context.Context, event interface{}) error
+
+func (f EventHandlerFunc) Handle(ctx context.Context, event interface{}) error {
+	return f(ctx, event)
+}
+
+type EventBus struct {
+	resolver Resolver
+	handlers map[reflect.Type][]interface{}
+	mu       sync.RWMutex
+}
+
+func NewEventBus(resolver Resolver) *EventBus {
+	return &EventBus{
+		resolver: resolver,
+		handlers: make(map[reflect.Type][]interface{}),
+	}
+}
+
+func (b *EventBus) Subscribe(eventType interface{}, factory interface{}) error {
+	factoryType := reflect.TypeOf(factory)
+	if factoryType == nil || factoryType.Kind() != reflect.Func {
+		return newError(ErrCodeInvalidFactory, nil, "handler factory must be a function")
+	}
+
+	if err := validateFactoryResults(factoryType); err != nil {
+		return err
+	}
+
+	handlerInterface := reflect.TypeOf((*EventHandler)(nil)).Elem()
+	if !factoryType.Out(0).Implements(handlerInterface) {
+		return newError(ErrCodeTypeMismatch, factoryType.Out(0), "handler factory return type %s does not implement EventHandler", factoryType.Out(0).String())
+	}
+
+	if eventType == nil {
+		return newError(ErrCodeInvalidArgument, nil, "event type must not be nil")
+	}
+	b.subscribeType(eventKey(reflect.TypeOf(eventType)), factory)
+	return nil
+}
+
+func (b *EventBus) subscribeType(eventType reflect.Type, factory interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], factory)
+}
+
+func (b *EventBus) HasSubscribers(eventType interface{}) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if eventType == nil {
+		return false
+	}
+	return len(b.handlers[eventKey(reflect.TypeOf(eventType))]) > 0
+}
+
+// eventKey is the type handlers of events of type t are subscribed under.
+// An event type and its pointer share a key, so Subscribe((*E)(nil), ...),
+// Subscribe[E], Subscribe[*E], Publish and Dispatch all agree.
+func eventKey(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func errNilEvent() error {
+	return newError(ErrCodeInvalidArgument, nil, "event must not be nil")
+}
+
+// Publish resolves every handler subscribed to the event's type and invokes
+// them in subscription order. All handlers run even if one fails; the
+// returned error joins every failure.
+func (b *EventBus) Publish(ctx context.Context, event interface{}) error {
+	if event == nil {
+		return errNilEvent()
+	}
+	return b.publishType(ctx, eventKey(reflect.TypeOf(event)), event)
+}
+
+func (b *EventBus) publishType(ctx context.Context, eventType reflect.Type, event interface{}) error {
+	var errs []error
+	for _, factory := range b.factoriesFor(eventType) {
+		if err := dispatch(ctx, b.resolver, factory, eventType, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync runs every handler on its own goroutine. The returned channel
+// receives the joined error (nil on success) once all handlers have finished.
+func (b *EventBus) PublishAsync(ctx context.Context, event interface{}) <-chan error {
+	done := make(chan error, 1)
+	if event == nil {
+		done <- errNilEvent()
+		return done
+	}
+	eventType := eventKey(reflect.TypeOf(event))
+	factories := b.factoriesFor(eventType)
+	resolver := b.resolver
+	if c, ok := resolver.(*Container); ok {
+		resolver = c.withCaller()
+	}
+
+	go func() {
+		errs := make([]error, len(factories))
+		var wg sync.WaitGroup
+		for i, factory := range factories {
+			wg.Add(1)
+			go func(i int, factory interface{}) {
+				defer wg.Done()
+				errs[i] = dispatch(ctx, resolver, factory, eventType, event)
+			}(i, factory)
+		}
+		wg.Wait()
+		done <- errors.Join(errs...)
+	}()
+
+	return done
+}
+
+func (b *EventBus) factoriesFor(eventType reflect.Type) []interface{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	factories := b.handlers[eventType]
+	return append([]interface{}(nil), factories...)
+}
+
+func dispatch(ctx context.Context, resolver Resolver, factory interface{}, eventType reflect.Type, event interface{}) error {
+	handler, err := invokeFactory(resolver, factory)
+	if err != nil {
+		return fmt.Errorf("failed to resolve handler for %s: %w", eventType.String(), err)
+	}
+	if handler == nil {
+		return newError(ErrCodeFactoryError, eventType, "handler factory for %s returned nil", eventType.String())
+	}
+	return handler.(EventHandler).Handle(ctx, event)
+}
+
+type TypedEventHandler[E any] interface {
+	Handle(ctx context.Context, event E) error
+}
+
+type TypedEventHandlerFunc[E any] func(ctx context.Context, event E) error
+
+func (f TypedEventHandlerFunc[E]) Handle(ctx context.Context, event E) error {
+	return f(ctx, event)
+}
+
+func (c *Container) Events() *EventBus {
+	c.eventsOnce.Do(func() {
+		c.events = NewEventBus(&Container{containerCore: c.containerCore})
+	})
+	return c.events
+}
+
+// Subscribe registers a typed handler for events of type E. Handlers of E
+// and of *E share a key, as with the untyped EventBus, and receive the
+// event converted to their type.
+func Subscribe[E any](container *Container, factory func(*Container) TypedEventHandler[E]) error {
+	if factory == nil {
+		return newError(ErrCodeInvalidFactory, nil, "handler factory must not be nil")
+	}
+
+	eventType := reflect.TypeFor[E]()
+	container.Events().subscribeType(eventKey(eventType), func(c *Container) (EventHandler, error) {
+		handler := factory(c)
+		if handler == nil {
+			return nil, newError(ErrCodeFactoryError, eventType, "handler factory for %s returned nil", eventType.String())
+		}
+		return EventHandlerFunc(func(ctx context.Context, event interface{}) error {
+			typed, ok := eventAs[E](event)
+			if !ok {
+				return newError(ErrCodeTypeMismatch, eventType, "event of type %T does not match subscribed type %s", event, eventType.String())
+			}
+			return handler.Handle(ctx, typed)
+		}), nil
+	})
+	return nil
+}
+
+func Dispatch[E any](container *Container, ctx context.Context, event E) error {
+	if any(event) == nil {
+		return errNilEvent()
+	}
+	return container.Events().publishType(ctx, eventKey(reflect.TypeFor[E]()), event)
+}
+
+// eventAs converts event to E, dereferencing it or taking the address of a
+// copy when only one of them is a pointer.
+func eventAs[E any](event interface{}) (E, bool) {
+	if typed, ok := event.(E); ok {
+		return typed, true
+	}
+	var zero E
+	eventType := reflect.TypeFor[E]()
+	value := reflect.ValueOf(event)
+	switch {
+	case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Type() == eventType:
+		return value.Elem().Interface().(E), true
+	case eventType.Kind() == reflect.Ptr && value.IsValid() && value.Type() == eventType.Elem():
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return ptr.Interface().(E), true
+	}
+	return zero, false
+}
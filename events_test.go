@@ -0,0 +1,252 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type TestUserCreated struct {
+	Name string
+}
+
+type TestOrderPlaced struct {
+	ID int
+}
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	received []string
+	prefix   string
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, event interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.received = append(h.received, h.prefix+event.(TestUserCreated).Name)
+	return nil
+}
+
+func TestEventBusPublish(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "dep:"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+
+	bus := NewEventBus(container)
+	handler := &recordingHandler{}
+
+	err = bus.Subscribe((*TestUserCreated)(nil), func(dep TestInterface) EventHandler {
+		handler.prefix = dep.GetValue()
+		return handler
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	var orders int
+	err = bus.Subscribe((*TestOrderPlaced)(nil), func() EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event interface{}) error {
+			orders++
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), TestUserCreated{Name: "alice"}); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	if len(handler.received) != 1 || handler.received[0] != "dep:alice" {
+		t.Errorf("Handler should receive the event with injected dependencies, got %v", handler.received)
+	}
+	if orders != 0 {
+		t.Error("Handlers for other event types should not be invoked")
+	}
+}
+
+func TestEventBusPublishAggregatesErrors(t *testing.T) {
+	bus := NewEventBus(NewContainer())
+
+	calls := 0
+	for i := 0; i < 2; i++ {
+		err := bus.Subscribe((*TestOrderPlaced)(nil), func() EventHandler {
+			return EventHandlerFunc(func(ctx context.Context, event interface{}) error {
+				calls++
+				return errors.New("handler failed")
+			})
+		})
+		if err != nil {
+			t.Fatalf("Failed to subscribe handler: %v", err)
+		}
+	}
+
+	err := bus.Subscribe((*TestOrderPlaced)(nil), func(dep TestInterface) EventHandler {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	err = bus.Publish(context.Background(), &TestOrderPlaced{ID: 1})
+	if err == nil {
+		t.Fatal("Expected error from failing handlers")
+	}
+	if calls != 2 {
+		t.Errorf("All handlers should run even when one fails, ran %d", calls)
+	}
+}
+
+func TestEventBusPublishAsync(t *testing.T) {
+	bus := NewEventBus(NewContainer())
+	handler := &recordingHandler{}
+
+	for i := 0; i < 3; i++ {
+		err := bus.Subscribe((*TestUserCreated)(nil), func() EventHandler {
+			return handler
+		})
+		if err != nil {
+			t.Fatalf("Failed to subscribe handler: %v", err)
+		}
+	}
+
+	if err := <-bus.PublishAsync(context.Background(), TestUserCreated{Name: "bob"}); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+
+	if len(handler.received) != 3 {
+		t.Errorf("Expected 3 deliveries, got %d", len(handler.received))
+	}
+}
+
+func TestEventBusSubscribeValidation(t *testing.T) {
+	bus := NewEventBus(NewContainer())
+
+	if err := bus.Subscribe((*TestUserCreated)(nil), "not a function"); err == nil {
+		t.Error("Expected error for non-function factory")
+	}
+
+	if err := bus.Subscribe((*TestUserCreated)(nil), func() string { return "" }); err == nil {
+		t.Error("Expected error when factory does not return an EventHandler")
+	}
+
+	if bus.HasSubscribers((*TestUserCreated)(nil)) {
+		t.Error("Invalid subscriptions should not be recorded")
+	}
+}
+
+func TestSubscribeAndDispatch(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "typed:"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+
+	var received []string
+	err = Subscribe[TestUserCreated](container, func(c *Container) TypedEventHandler[TestUserCreated] {
+		dep := MustResolve[TestInterface](c)
+		return TypedEventHandlerFunc[TestUserCreated](func(ctx context.Context, event TestUserCreated) error {
+			received = append(received, dep.GetValue()+event.Name)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	var pointerEvents int
+	err = Subscribe[*TestUserCreated](container, func(c *Container) TypedEventHandler[*TestUserCreated] {
+		return TypedEventHandlerFunc[*TestUserCreated](func(ctx context.Context, event *TestUserCreated) error {
+			pointerEvents++
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	if err := Dispatch(container, context.Background(), TestUserCreated{Name: "carol"}); err != nil {
+		t.Fatalf("Failed to dispatch event: %v", err)
+	}
+
+	if len(received) != 1 || received[0] != "typed:carol" {
+		t.Errorf("Typed handler should receive the event, got %v", received)
+	}
+	if pointerEvents != 1 {
+		t.Error("Handlers for *E should receive events of type E")
+	}
+
+	if err := Dispatch(container, context.Background(), &TestUserCreated{Name: "dave"}); err != nil {
+		t.Fatalf("Failed to dispatch event: %v", err)
+	}
+	if pointerEvents != 2 || len(received) != 2 || received[1] != "typed:dave" {
+		t.Errorf("Pointer events should reach handlers for E and *E, got %v", received)
+	}
+}
+
+func TestEventsAcrossTypedAndUntypedAPIs(t *testing.T) {
+	container := NewContainer()
+	bus := container.Events()
+
+	var received []string
+	err := bus.Subscribe((*TestUserCreated)(nil), func() EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event interface{}) error {
+			received = append(received, "untyped")
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+	err = Subscribe[*TestUserCreated](container, func(c *Container) TypedEventHandler[*TestUserCreated] {
+		return TypedEventHandlerFunc[*TestUserCreated](func(ctx context.Context, event *TestUserCreated) error {
+			received = append(received, "typed:"+event.Name)
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	if !bus.HasSubscribers((*TestUserCreated)(nil)) {
+		t.Error("Typed subscriptions should be visible to the untyped API")
+	}
+	if err := bus.Publish(context.Background(), TestUserCreated{Name: "erin"}); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+	if err := Dispatch(container, context.Background(), TestUserCreated{Name: "frank"}); err != nil {
+		t.Fatalf("Failed to dispatch event: %v", err)
+	}
+	if strings.Join(received, ",") != "untyped,typed:erin,untyped,typed:frank" {
+		t.Errorf("Expected both APIs to reach every handler, got %v", received)
+	}
+
+	if err := bus.Publish(context.Background(), nil); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT publishing nil, got %v", err)
+	}
+	if err := <-bus.PublishAsync(context.Background(), nil); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT publishing nil asynchronously, got %v", err)
+	}
+	if err := Dispatch[error](container, context.Background(), nil); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT dispatching nil, got %v", err)
+	}
+}
+
+func TestDispatchWithoutSubscribers(t *testing.T) {
+	container := NewContainer()
+
+	if err := Dispatch(container, context.Background(), TestOrderPlaced{ID: 1}); err != nil {
+		t.Errorf("Dispatch without subscribers should succeed, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// EvictSingleton drops the cached instance of the singleton T, shutting it
+// down or closing it like Close does, while keeping the registration. The next
+// resolution builds a fresh instance. A cached construction failure is
+// forgotten as well.
+func EvictSingleton[T any](c *Container) error {
+	return c.evictSingleton(reflect.TypeFor[T]())
+}
+
+func (c *Container) evictSingleton(serviceType reflect.Type) error {
+	c.mu.Lock()
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType}]
+	if !exists {
+		defer c.mu.Unlock()
+		return c.notRegisteredError(serviceType)
+	}
+	if descriptor.Lifecycle != Singleton {
+		c.mu.Unlock()
+		return newError(ErrCodeInvalidArgument, serviceType, "service of type %s is not a singleton", serviceType.String())
+	}
+
+	instance, cleanup, _ := descriptor.dropInstance()
+	c.recordAudit(AuditRecord{
+		Action:      AuditEvict,
+		ServiceType: serviceType,
+		Lifecycle:   descriptor.Lifecycle,
+	})
+	c.mu.Unlock()
+
+	return closeEvicted(serviceType, instance, cleanup)
+}
+
+// ResetSingletons drops every cached singleton instance while keeping the
+// registrations, so an expensive container setup can be shared by tests that
+// need fresh singletons. Instances are disposed like Close does, most
+// recently created first.
+func (c *Container) ResetSingletons() error {
+	type evicted struct {
+		serviceType reflect.Type
+		instance    interface{}
+		cleanup     func()
+		built       uint64
+	}
+
+	c.mu.Lock()
+	var instances []evicted
+	for _, descriptor := range c.services {
+		if descriptor.Lifecycle != Singleton {
+			continue
+		}
+		if instance, cleanup, built := descriptor.dropInstance(); instance != nil {
+			instances = append(instances, evicted{descriptor.ServiceType, instance, cleanup, built})
+		}
+	}
+	c.recordAudit(AuditRecord{Action: AuditReset})
+	c.mu.Unlock()
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].built > instances[j].built
+	})
+
+	var errs []error
+	for _, e := range instances {
+		if err := closeEvicted(e.serviceType, e.instance, e.cleanup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithReplacementGracePeriod makes the container dispose the singleton
+// displaced by a re-registration or Replace once grace has passed, like
+// Close does. Resolutions already running finish against the old
+// registration, and callers still holding the old instance have until then
+// to finish with it. Without this option singletons displaced by a
+// re-registration are left for the garbage collector, and those displaced
+// by Replace are disposed immediately.
+func WithReplacementGracePeriod(grace time.Duration) ContainerOption {
+	return func(c *Container) {
+		c.replaceGrace = grace
+	}
+}
+
+// disposeAfterGrace closes the singleton of a displaced descriptor once the
+// grace period ends. The instance is taken only then, so one built by a
+// straggling resolution is closed too.
+func (c *Container) disposeAfterGrace(displaced *ServiceDescriptor) {
+	if displaced.Lifecycle != Singleton {
+		return
+	}
+	time.AfterFunc(c.replaceGrace, func() {
+		instance, cleanup, _ := displaced.dropInstance()
+		if err := closeEvicted(displaced.ServiceType, instance, cleanup); err != nil {
+			c.report(displaced.ServiceType, err, false)
+		}
+	})
+}
+
+// dropInstance clears the cached instance and returns it with the cleanup
+// function its factory returned and its creation sequence number.
+func (d *ServiceDescriptor) dropInstance() (interface{}, func(), uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	instance, cleanup, built := d.instance, d.cleanup, d.built
+	d.instance, d.cleanup, d.built = nil, nil, 0
+	// The failure is owned by an in-flight construction, if there is one
+	if d.inflight == nil {
+		d.failure = nil
+	}
+	return instance, cleanup, built
+}
+
+// closeEvicted disposes instance like Close does, then runs the cleanup
+// function its factory returned.
+func closeEvicted(serviceType reflect.Type, instance interface{}, cleanup func()) error {
+	defer runCleanup(cleanup)
+	if err := dispose(instance); err != nil {
+		return fmt.Errorf("failed to close evicted %s: %w", serviceType.String(), err)
+	}
+	return nil
+}
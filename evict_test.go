@@ -0,0 +1,248 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvictSingleton(t *testing.T) {
+	container := NewContainer()
+
+	var created []*TestConnection
+	err := RegisterSingletonType[*TestConnection](container, func(c *Container) *TestConnection {
+		conn := &TestConnection{id: len(created) + 1}
+		created = append(created, conn)
+		return conn
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	first := MustResolve[*TestConnection](container)
+	if err := EvictSingleton[*TestConnection](container); err != nil {
+		t.Fatalf("Failed to evict singleton: %v", err)
+	}
+	if !first.closed {
+		t.Error("Evicted instance should be closed")
+	}
+
+	second := MustResolve[*TestConnection](container)
+	if second == first || second.id != 2 {
+		t.Error("Resolution after eviction should build a new instance")
+	}
+
+	log := container.AuditLog()
+	if log[len(log)-1].Action != AuditEvict {
+		t.Error("Eviction should be recorded in the audit log")
+	}
+}
+
+func TestEvictSingletonForgetsCachedFailure(t *testing.T) {
+	container := NewContainer()
+
+	fail := true
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		if fail {
+			panic("not ready")
+		}
+		return &TestImplementation{}
+	}, WithRetryPolicy(RetryPolicy{InitialBackoff: time.Hour}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	TryResolve[*TestImplementation](container)
+	fail = false
+	if err := EvictSingleton[*TestImplementation](container); err != nil {
+		t.Fatalf("Failed to evict singleton: %v", err)
+	}
+	if _, ok := TryResolve[*TestImplementation](container); !ok {
+		t.Error("Eviction should allow an immediate retry")
+	}
+}
+
+func TestEvictSingletonErrors(t *testing.T) {
+	container := NewContainer()
+
+	if err := EvictSingleton[*TestImplementation](container); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	err := RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	if err := EvictSingleton[*TestImplementation](container); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+
+	err = RegisterSingletonType[*failingCloser](container, func(c *Container) *failingCloser {
+		return &failingCloser{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	MustResolve[*failingCloser](container)
+	if err := EvictSingleton[*failingCloser](container); !errors.Is(err, errCloseFailed) {
+		t.Errorf("Expected close error, got %v", err)
+	}
+}
+
+var errCloseFailed = errors.New("close failed")
+
+type failingCloser struct{}
+
+func (failingCloser) Close() error {
+	return errCloseFailed
+}
+
+type orderedCloser struct {
+	name   string
+	closed *[]string
+}
+
+func (c *orderedCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+type otherCloser struct {
+	orderedCloser
+}
+
+func TestResetSingletons(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	builds := 0
+	err := RegisterSingletonType[*orderedCloser](container, func(c *Container) *orderedCloser {
+		builds++
+		return &orderedCloser{name: "first", closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterSingletonType[*otherCloser](container, func(c *Container) *otherCloser {
+		MustResolve[*orderedCloser](c)
+		return &otherCloser{orderedCloser{name: "second", closed: &closed}}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	MustResolve[*otherCloser](container)
+	if err := container.ResetSingletons(); err != nil {
+		t.Fatalf("Failed to reset singletons: %v", err)
+	}
+	if len(closed) != 2 || closed[0] != "second" || closed[1] != "first" {
+		t.Errorf("Singletons should be closed in reverse creation order, got %v", closed)
+	}
+
+	MustResolve[*orderedCloser](container)
+	if builds != 2 {
+		t.Error("Singletons should be rebuilt after a reset")
+	}
+	if !container.Has((**otherCloser)(nil)) {
+		t.Error("Registrations should be kept")
+	}
+}
+
+type closeSignal chan struct{}
+
+func (c closeSignal) Close() error {
+	close(c)
+	return nil
+}
+
+func TestReplacementGracePeriod(t *testing.T) {
+	container := NewContainer(WithReplacementGracePeriod(20 * time.Millisecond))
+
+	old := make(closeSignal)
+	err := container.RegisterSingleton((*closeSignal)(nil), func() closeSignal { return old })
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	if _, err := container.Resolve((*closeSignal)(nil)); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+
+	replacement := make(closeSignal)
+	err = container.RegisterSingleton((*closeSignal)(nil), func() closeSignal { return replacement })
+	if err != nil {
+		t.Fatalf("Failed to re-register service: %v", err)
+	}
+
+	select {
+	case <-old:
+		t.Fatal("The displaced singleton should not be closed before the grace period")
+	default:
+	}
+	select {
+	case <-old:
+	case <-time.After(time.Second):
+		t.Fatal("The displaced singleton should be closed after the grace period")
+	}
+
+	service, err := container.Resolve((*closeSignal)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve replacement: %v", err)
+	}
+	if service.(closeSignal) != replacement {
+		t.Error("Expected new resolutions to see the replacement")
+	}
+}
+
+func TestEvictSingletonShutsDown(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	err := RegisterSingletonType[*shutdownRecorder](container, func(c *Container) *shutdownRecorder {
+		return &shutdownRecorder{closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	MustResolve[*shutdownRecorder](container)
+	if err := EvictSingleton[*shutdownRecorder](container); err != nil {
+		t.Fatalf("Failed to evict singleton: %v", err)
+	}
+	MustResolve[*shutdownRecorder](container)
+	if err := container.ResetSingletons(); err != nil {
+		t.Fatalf("Failed to reset singletons: %v", err)
+	}
+	if len(closed) != 2 {
+		t.Errorf("Evicted Shutdowner instances should be shut down, got %v", closed)
+	}
+}
+
+func TestReplaceDrainsWithGracePeriod(t *testing.T) {
+	container := NewContainer(WithReplacementGracePeriod(20 * time.Millisecond))
+
+	old := make(closeSignal)
+	err := container.RegisterSingleton((*closeSignal)(nil), func() closeSignal { return old })
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	if _, err := container.Resolve((*closeSignal)(nil)); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+
+	err = container.Replace((*closeSignal)(nil), func() closeSignal { return make(closeSignal) }, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to replace service: %v", err)
+	}
+	select {
+	case <-old:
+		t.Fatal("Replace should leave the old instance to callers until the grace period ends")
+	default:
+	}
+	select {
+	case <-old:
+	case <-time.After(time.Second):
+		t.Fatal("The replaced singleton should be closed after the grace period")
+	}
+}
@@ -230,26 +230,30 @@ func setupContainer() *inject.Container {
 		return &UserServiceImpl{repo: repo, logger: logger}
 	})
 
-	// Register HTTP handler as transient (though typically would be singleton in real apps)
-	inject.RegisterTransientType[*UserHandler](container, func(c *inject.Container) *UserHandler {
+	// Register HTTP handler per request; HTTPMiddleware opens the request scope
+	inject.RegisterType[*UserHandler](container, func(c *inject.Container) *UserHandler {
 		userService := inject.MustResolve[UserService](c)
 		logger := inject.MustResolve[Logger](c)
 		return &UserHandler{userService: userService, logger: logger}
-	})
+	}, inject.Scoped)
 
 	return container
 }
 
+func requestHandler(r *http.Request) *UserHandler {
+	scope, _ := inject.ScopeFromContext(r.Context())
+	return inject.MustResolve[*UserHandler](scope)
+}
+
 func main() {
 	fmt.Println("=== Web Service with Dependency Injection Example ===")
 
 	container := setupContainer()
 
-	// Get handler from container
-	handler := inject.MustResolve[*UserHandler](container)
-
-	// Setup routes
-	http.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+	// Setup routes, resolving the handler from each request's scope
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		handler := requestHandler(r)
 		if r.Method == http.MethodGet {
 			handler.GetAllUsers(w, r)
 		} else if r.Method == http.MethodPost {
@@ -259,7 +263,9 @@ func main() {
 		}
 	})
 
-	http.HandleFunc("/users/", handler.GetUser)
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		requestHandler(r).GetUser(w, r)
+	})
 
 	// Create some sample data
 	userService := inject.MustResolve[UserService](container)
@@ -274,6 +280,7 @@ func main() {
 
 	server := &http.Server{
 		Addr:         ":8080",
+		Handler:      inject.HTTPMiddleware(container)(mux),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
@@ -0,0 +1,116 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFallbackFactoryUsedWhenPrimaryFails(t *testing.T) {
+	container := NewContainer()
+
+	redisDown := true
+	err := container.RegisterTransient((*TestInterface)(nil), func() (TestInterface, error) {
+		if redisDown {
+			return nil, errors.New("redis unavailable")
+		}
+		return &TestImplementation{value: "redis"}, nil
+	}, WithFallback(func() TestInterface {
+		return &TestImplementation{value: "memory"}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	service, err := container.Resolve((*TestInterface)(nil))
+	if err != nil {
+		t.Fatalf("Fallback should hide the primary failure: %v", err)
+	}
+	if service.(TestInterface).GetValue() != "memory" {
+		t.Error("Fallback instance should be returned")
+	}
+
+	degraded := container.Stats().DegradedServices()
+	if len(degraded) != 1 || degraded[0].DegradedBy == nil || degraded[0].DegradedBy.Error() != "redis unavailable" {
+		t.Fatalf("Degradation should be reported in stats, got %+v", degraded)
+	}
+
+	redisDown = false
+	service, err = container.Resolve((*TestInterface)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service.(TestInterface).GetValue() != "redis" {
+		t.Error("Primary factory should be used once it recovers")
+	}
+	if len(container.Stats().DegradedServices()) != 0 {
+		t.Error("Degradation should clear once the primary factory succeeds")
+	}
+}
+
+func TestFallbackFactoryOnDependencyFailure(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	}, WithFallback(func() *TestService {
+		return &TestService{dependency: &TestImplementation{value: "offline"}}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	service, err := container.Resolve((*TestService)(nil))
+	if err != nil {
+		t.Fatalf("Fallback should cover missing dependencies: %v", err)
+	}
+	if service.(*TestService).GetDependency().GetValue() != "offline" {
+		t.Error("Fallback instance should be returned")
+	}
+}
+
+func TestFallbackFactoryFailure(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		return nil, errors.New("primary failed")
+	}, WithFallback(func() (*TestImplementation, error) {
+		return nil, errors.New("fallback failed")
+	}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestImplementation)(nil))
+	if err == nil {
+		t.Fatal("Expected error when both factories fail")
+	}
+	if !strings.Contains(err.Error(), "primary failed") || !strings.Contains(err.Error(), "fallback failed") {
+		t.Errorf("Error should mention both failures, got '%s'", err.Error())
+	}
+	if ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Expected %s, got %s", ErrCodeFactoryError, ErrorCodeOf(err))
+	}
+}
+
+func TestFallbackFactoryValidation(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{}
+	}, WithFallback("not a function"))
+	if ErrorCodeOf(err) != ErrCodeInvalidFactory {
+		t.Errorf("Expected %s for non-function fallback, got %v", ErrCodeInvalidFactory, err)
+	}
+
+	err = container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{}
+	}, WithFallback(func() *TestRepository { return nil }))
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected %s for mismatched fallback, got %v", ErrCodeTypeMismatch, err)
+	}
+
+	if container.Has((*TestImplementation)(nil)) {
+		t.Error("Invalid registrations should not be stored")
+	}
+}
@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"context"
+	"reflect"
+)
+
+// Future is the pending result of ResolveAsync.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// ResolveAsync starts resolving T on a new goroutine so independent services
+// can be built concurrently; collect the result with Await.
+func ResolveAsync[T any](container Resolver) *Future[T] {
+	if c, ok := container.(*Container); ok {
+		container = c.withCaller()
+	}
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				serviceType := reflect.TypeFor[T]()
+				f.err = newError(ErrCodeFactoryPanic, serviceType, "asynchronous resolution of %s panicked: %v", serviceType.String(), r)
+			}
+		}()
+		f.value, f.err = resolveAs[T](container)
+	}()
+	return f
+}
+
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await waits for the resolution to finish or ctx to end. Resolution keeps
+// running when ctx ends first, and a later Await still returns its result.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
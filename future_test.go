@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolveAsync(t *testing.T) {
+	container := NewContainer()
+
+	release := make(chan struct{})
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		<-release
+		return &TestImplementation{value: "async"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	future := ResolveAsync[*TestImplementation](container)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := future.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Await should stop when the context ends, got %v", err)
+	}
+
+	close(release)
+	<-future.Done()
+
+	service, err := future.Await(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if service.GetValue() != "async" {
+		t.Error("Expected the resolved service")
+	}
+}
+
+func TestResolveAsyncError(t *testing.T) {
+	container := NewContainer()
+
+	future := ResolveAsync[*TestImplementation](container)
+	if _, err := future.Await(context.Background()); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+}
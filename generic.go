@@ -0,0 +1,185 @@
+package inject
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// genericKey identifies a generic type independently of its type arguments,
+// e.g. every *Repository[T] from one package.
+type genericKey struct {
+	pkgPath  string
+	name     string
+	pointers int
+}
+
+type genericTemplate struct {
+	serviceType reflect.Type
+	factory     func(*Container, reflect.Type) (interface{}, error)
+	lifecycle   Lifecycle
+	opts        []RegistrationOption
+	mu          sync.Mutex
+	closed      map[reflect.Type]*ServiceDescriptor
+}
+
+func genericKeyOf(t reflect.Type) (genericKey, bool) {
+	key := genericKey{}
+	for t.Kind() == reflect.Ptr && t.Name() == "" {
+		key.pointers++
+		t = t.Elem()
+	}
+	name, _, generic := strings.Cut(t.Name(), "[")
+	if !generic {
+		return key, false
+	}
+	key.pkgPath, key.name = t.PkgPath(), name
+	return key, true
+}
+
+// RegisterOpenGeneric registers a factory for every instantiation of the
+// generic type that T instantiates, so Repository[User] and
+// Repository[Order] need no registration each:
+//
+//	inject.RegisterOpenGeneric[*Repository[any]](c, func(c *inject.Container, t reflect.Type) (interface{}, error) {
+//	    repo := reflect.New(t.Elem())
+//	    ... // fill in fields that do not depend on the type argument
+//	    return repo.Interface(), nil
+//	}, inject.Singleton)
+//
+// The factory receives the requested type and must return a value of that
+// type. Each instantiation gets its own descriptor, so singletons are
+// cached per type argument, and Validate, Stats and Close see the
+// instantiations built so far. Open generics can be registered on a
+// container or a transaction, but not on containers created with
+// WithNoReflection.
+func RegisterOpenGeneric[T any](container Registrar, factory func(c *Container, serviceType reflect.Type) (interface{}, error), lifecycle Lifecycle, opts ...RegistrationOption) error {
+	serviceType := reflect.TypeFor[T]()
+	key, ok := genericKeyOf(serviceType)
+	if !ok {
+		return newError(ErrCodeInvalidArgument, serviceType, "%s is not an instantiated generic type", serviceType.String())
+	}
+	if factory == nil {
+		return newError(ErrCodeInvalidFactory, serviceType, "factory must be a function")
+	}
+	r, ok := container.(genericRegistrar)
+	if !ok {
+		return newError(ErrCodeInvalidArgument, serviceType, "open generics can only be registered on a container or transaction")
+	}
+	return r.registerGeneric(key, &genericTemplate{
+		serviceType: serviceType,
+		factory:     factory,
+		lifecycle:   lifecycle,
+		opts:        opts,
+		closed:      make(map[reflect.Type]*ServiceDescriptor),
+	})
+}
+
+// genericRegistrar is implemented by registrars that accept open generic
+// registrations.
+type genericRegistrar interface {
+	registerGeneric(key genericKey, template *genericTemplate) error
+}
+
+func (c *Container) registerGeneric(key genericKey, template *genericTemplate) error {
+	if c.noReflection {
+		return errGenericReflection(template.serviceType)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.installGeneric(key, template)
+	return nil
+}
+
+// installGeneric makes template the open generic registration for key.
+// Callers must hold c.mu.
+func (c *Container) installGeneric(key genericKey, template *genericTemplate) {
+	if c.generics == nil {
+		c.generics = make(map[genericKey]*genericTemplate)
+	}
+	_, replaced := c.generics[key]
+	c.generics[key] = template
+	c.recordAudit(AuditRecord{
+		Action:      AuditRegister,
+		ServiceType: template.serviceType,
+		Lifecycle:   template.lifecycle,
+		Replaced:    replaced,
+	})
+}
+
+func errGenericReflection(serviceType reflect.Type) error {
+	return newError(ErrCodeReflectionDisabled, serviceType, "open generics are disabled on containers created with WithNoReflection")
+}
+
+// genericKeyRegistered reports whether serviceType instantiates an open
+// generic registration. Callers must hold c.mu.
+func (c *Container) genericKeyRegistered(serviceType reflect.Type) (genericKey, bool) {
+	key, ok := genericKeyOf(serviceType)
+	if !ok {
+		return key, false
+	}
+	_, exists := c.generics[key]
+	return key, exists
+}
+
+// genericDescriptor returns the descriptor for an instantiation of an open
+// generic registration, creating it on first use. Callers must hold c.mu.
+func (c *Container) genericDescriptor(serviceType reflect.Type) (*ServiceDescriptor, bool) {
+	key, exists := c.genericKeyRegistered(serviceType)
+	if !exists {
+		return nil, false
+	}
+	template := c.generics[key]
+
+	template.mu.Lock()
+	defer template.mu.Unlock()
+
+	if descriptor, exists := template.closed[serviceType]; exists {
+		return descriptor, true
+	}
+	create := func(c *Container) (interface{}, error) {
+		instance, err := template.factory(c, serviceType)
+		if err != nil {
+			return nil, err
+		}
+		if instance == nil || !reflect.TypeOf(instance).AssignableTo(serviceType) {
+			return nil, newError(ErrCodeTypeMismatch, serviceType, "open generic factory returned %T for %s", instance, serviceType.String())
+		}
+		return instance, nil
+	}
+	// Factory has the signature of a registration of serviceType, so
+	// Validate and reports treat the instantiation like one
+	factoryType := reflect.FuncOf([]reflect.Type{reflect.TypeOf((*Container)(nil))}, []reflect.Type{serviceType, reflect.TypeFor[error]()}, false)
+	descriptor := &ServiceDescriptor{
+		ServiceType: serviceType,
+		Factory: reflect.MakeFunc(factoryType, func(args []reflect.Value) []reflect.Value {
+			instance, err := create(args[0].Interface().(*Container))
+			return []reflect.Value{valueOrZero(serviceType, instance), reflect.ValueOf(&err).Elem()}
+		}).Interface(),
+		Lifecycle: template.lifecycle,
+		create:    create,
+	}
+	for _, opt := range template.opts {
+		opt(descriptor)
+	}
+	template.closed[serviceType] = descriptor
+	return descriptor, true
+}
+
+// genericInstances returns the descriptors created so far for
+// instantiations of open generic registrations, in type order. Callers must
+// hold c.mu.
+func (c *Container) genericInstances() []*ServiceDescriptor {
+	var descriptors []*ServiceDescriptor
+	for _, template := range c.generics {
+		template.mu.Lock()
+		for _, descriptor := range template.closed {
+			descriptors = append(descriptors, descriptor)
+		}
+		template.mu.Unlock()
+	}
+	sortDescriptors(descriptors)
+	return descriptors
+}
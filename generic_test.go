@@ -0,0 +1,158 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type Repository[T any] struct {
+	Table string
+	Items []T
+}
+
+type User struct{}
+
+type Order struct{}
+
+func TestOpenGenericRegistration(t *testing.T) {
+	container := NewContainer()
+
+	builds := 0
+	err := RegisterOpenGeneric[*Repository[any]](container, func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		builds++
+		repo := reflect.New(serviceType.Elem())
+		repo.Elem().FieldByName("Table").SetString(serviceType.Elem().Name())
+		return repo.Interface(), nil
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register open generic: %v", err)
+	}
+
+	users := MustResolve[*Repository[User]](container)
+	orders := MustResolve[*Repository[Order]](container)
+	if users.Table == orders.Table {
+		t.Error("Each instantiation should get its own instance")
+	}
+	if MustResolve[*Repository[User]](container) != users || builds != 2 {
+		t.Error("Singletons should be cached per type argument")
+	}
+	if !container.Has((**Repository[Order])(nil)) {
+		t.Error("Instantiations of an open generic should be reported as registered")
+	}
+	if container.Has((*Repository[User])(nil)) {
+		t.Error("Pointer depth should be part of the generic key")
+	}
+}
+
+func TestOpenGenericErrors(t *testing.T) {
+	container := NewContainer()
+
+	if err := RegisterOpenGeneric[*User](container, nil, Transient); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a non-generic type, got %v", err)
+	}
+
+	err := RegisterOpenGeneric[*Repository[any]](container, func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		if serviceType == reflect.TypeFor[*Repository[Order]]() {
+			return nil, errors.New("orders are not stored")
+		}
+		return &Repository[any]{}, nil
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register open generic: %v", err)
+	}
+
+	if _, ok := TryResolve[*Repository[User]](container); ok {
+		t.Error("Factory results of the wrong type should be rejected")
+	}
+	_, err = container.Resolve((**Repository[User])(nil))
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH, got %v", err)
+	}
+	_, err = container.Resolve((**Repository[Order])(nil))
+	if ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Expected FACTORY_ERROR, got %v", err)
+	}
+}
+
+type closingRepository[T any] struct {
+	closed bool
+}
+
+func (r *closingRepository[T]) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestOpenGenericClose(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterOpenGeneric[*closingRepository[any]](container, func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		return reflect.New(serviceType.Elem()).Interface(), nil
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register open generic: %v", err)
+	}
+
+	users := MustResolve[*closingRepository[User]](container)
+	var created uint64
+	for _, service := range container.Stats().Services {
+		if service.ServiceType == reflect.TypeFor[*closingRepository[User]]() {
+			created = service.Created
+		}
+	}
+	if created != 1 {
+		t.Errorf("Stats should report open generic instantiations, got %d created", created)
+	}
+
+	if err := container.Close(); err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if !users.closed {
+		t.Error("Close should dispose open generic singletons")
+	}
+}
+
+func TestOpenGenericRegistrars(t *testing.T) {
+	container := NewContainer()
+
+	tx := container.Begin()
+	err := RegisterOpenGeneric[*Repository[any]](tx, func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		return reflect.New(serviceType.Elem()).Interface(), nil
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to stage open generic: %v", err)
+	}
+	err = tx.RegisterSingleton((*TestService)(nil), func(users *Repository[User]) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to stage service: %v", err)
+	}
+	if container.Has((**Repository[User])(nil)) {
+		t.Error("Staged open generics should not be visible before Commit")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+	if _, err := container.Resolve((*TestService)(nil)); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Validate should accept instantiations of open generics: %v", err)
+	}
+
+	strict := NewContainer(WithNoReflection())
+	err = RegisterOpenGeneric[*Repository[any]](strict, func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		return nil, nil
+	}, Singleton)
+	if ErrorCodeOf(err) != ErrCodeReflectionDisabled {
+		t.Errorf("Expected REFLECTION_DISABLED under WithNoReflection, got %v", err)
+	}
+	err = RegisterOpenGeneric[*Repository[any]](container.Profile("test"), func(c *Container, serviceType reflect.Type) (interface{}, error) {
+		return nil, nil
+	}, Singleton)
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT from a profile registrar, got %v", err)
+	}
+}
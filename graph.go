@@ -0,0 +1,161 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// dependenciesOf lists the services descriptor's factory needs: its
+// parameters, plus any service it was seen resolving through the container
+// handle. Callers must hold c.mu.
+func (c *Container) dependenciesOf(descriptor *ServiceDescriptor) []reflect.Type {
+	seen := make(map[reflect.Type]bool)
+	var deps []reflect.Type
+
+	factoryType := reflect.TypeOf(descriptor.Factory)
+	var argTypes []reflect.Type
+	for i := 0; i < factoryType.NumIn(); i++ {
+		if argType := factoryType.In(i); isParamStruct(argType) {
+			for _, f := range paramFields(argType) {
+				if f.group == "" {
+					argTypes = append(argTypes, f.serviceType)
+				}
+			}
+		} else {
+			argTypes = append(argTypes, argType)
+		}
+	}
+	for _, argType := range argTypes {
+		if target, ok := lazyKey(argType); ok {
+			argType = target
+		}
+		if _, registered := c.services[serviceKey{serviceType: argType}]; !registered {
+			if elem, ok := c.collectionKey(argType); ok {
+				argType = elem
+			}
+		}
+		if argType == reflect.TypeOf((*Container)(nil)) || argType == contextType || seen[argType] {
+			continue
+		}
+		seen[argType] = true
+		deps = append(deps, argType)
+	}
+
+	var learned []reflect.Type
+	for _, other := range c.services {
+		if seen[other.ServiceType] {
+			continue
+		}
+		other.depMu.Lock()
+		_, depends := other.dependents[descriptor.ServiceType]
+		other.depMu.Unlock()
+		if depends {
+			seen[other.ServiceType] = true
+			learned = append(learned, other.ServiceType)
+		}
+	}
+	sort.Slice(learned, func(i, j int) bool {
+		return lessType(learned[i], learned[j])
+	})
+	return append(deps, learned...)
+}
+
+// descriptorByName finds a registration by the string form of its type, as
+// printed by reflect, e.g. "*db.Pool". Of several unnamed registrations the
+// one outside groups, registered first, wins. Callers must hold c.mu.
+func (c *Container) descriptorByName(name string) (*ServiceDescriptor, bool) {
+	for _, descriptor := range c.sortedDescriptors() {
+		if descriptor.Name == "" && descriptor.ServiceType.String() == name {
+			return descriptor, true
+		}
+	}
+	return nil, false
+}
+
+// DependencyGraph is a snapshot of the services in a container and the
+// dependencies between them.
+type DependencyGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode is a registration, or a dependency that is not registered. ID is
+// the service type, followed by the name of a named registration.
+type GraphNode struct {
+	ID          string
+	Lifecycle   Lifecycle
+	Description string
+	Registered  bool
+}
+
+type GraphEdge struct {
+	From string
+	To   string
+}
+
+// Graph returns the dependency graph of the container. Edges come from
+// factory parameters and from services seen resolving others through the
+// container handle.
+func (c *Container) Graph() *DependencyGraph {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	graph := &DependencyGraph{}
+	seen := make(map[string]bool)
+	var missing []string
+	for _, descriptor := range c.sortedDescriptors() {
+		id := descriptor.displayName()
+		if !seen[id] {
+			seen[id] = true
+			graph.Nodes = append(graph.Nodes, GraphNode{ID: id, Lifecycle: descriptor.Lifecycle, Description: descriptor.Description, Registered: true})
+		}
+		for _, dep := range c.dependenciesOf(descriptor) {
+			graph.Edges = append(graph.Edges, GraphEdge{From: id, To: dep.String()})
+			if _, ok := c.services[serviceKey{serviceType: dep}]; !ok {
+				missing = append(missing, dep.String())
+			}
+		}
+	}
+
+	sort.Strings(missing)
+	for _, id := range missing {
+		if !seen[id] {
+			seen[id] = true
+			graph.Nodes = append(graph.Nodes, GraphNode{ID: id})
+		}
+	}
+	return graph
+}
+
+// DOT renders the graph in the Graphviz DOT language, labelling each service
+// with its lifecycle, showing its description as a tooltip and drawing
+// unregistered dependencies dashed:
+//
+//	dot -Tsvg services.dot > services.svg
+func (g *DependencyGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph services {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for _, node := range g.Nodes {
+		switch {
+		case node.Registered && node.Description != "":
+			fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n%s\", tooltip=\"%s\"];\n", dotEscape(node.ID), dotEscape(node.ID), node.Lifecycle, dotEscape(node.Description))
+		case node.Registered:
+			fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n%s\"];\n", dotEscape(node.ID), dotEscape(node.ID), node.Lifecycle)
+		default:
+			fmt.Fprintf(&b, "\t\"%s\" [label=\"%s\\n(unregistered)\", style=dashed];\n", dotEscape(node.ID), dotEscape(node.ID))
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t\"%s\" -> \"%s\";\n", dotEscape(edge.From), dotEscape(edge.To))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
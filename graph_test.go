@@ -0,0 +1,74 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphDOT(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = container.RegisterTransient((**TestService)(nil), func(repo *TestRepository, impl *TestImplementation) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterNamed[*TestRepository](container, "replica", func(c *Container) *TestRepository {
+		return &TestRepository{}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register named repository: %v", err)
+	}
+
+	graph := container.Graph()
+	if len(graph.Nodes) != 4 {
+		t.Errorf("Expected 3 services and 1 unregistered dependency, got %v", graph.Nodes)
+	}
+	if len(graph.Edges) != 2 {
+		t.Errorf("Expected 2 edges, got %v", graph.Edges)
+	}
+
+	dot := graph.DOT()
+	for _, line := range []string{
+		`"*inject.TestRepository" [label="*inject.TestRepository\nSingleton"];`,
+		`"*inject.TestRepository \"replica\"" [label="*inject.TestRepository \"replica\"\nSingleton"];`,
+		`"*inject.TestService" [label="*inject.TestService\nTransient"];`,
+		`"*inject.TestImplementation" [label="*inject.TestImplementation\n(unregistered)", style=dashed];`,
+		`"*inject.TestService" -> "*inject.TestRepository";`,
+		`"*inject.TestService" -> "*inject.TestImplementation";`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("Expected DOT output to contain %s, got:\n%s", line, dot)
+		}
+	}
+	if !strings.HasPrefix(dot, "digraph services {") {
+		t.Errorf("Expected a digraph, got:\n%s", dot)
+	}
+}
+
+func TestGraphDOTDescription(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	}, WithDescription(`primary "orders" database`))
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	graph := container.Graph()
+	if len(graph.Nodes) != 1 || graph.Nodes[0].Description != `primary "orders" database` {
+		t.Errorf("Expected the node to carry the description, got %v", graph.Nodes)
+	}
+	line := `"*inject.TestRepository" [label="*inject.TestRepository\nSingleton", tooltip="primary \"orders\" database"];`
+	if dot := graph.DOT(); !strings.Contains(dot, line) {
+		t.Errorf("Expected DOT output to contain %s, got:\n%s", line, dot)
+	}
+}
@@ -0,0 +1,71 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithGroup adds the registration to the named group of its type. Any
+// number of registrations can join a group, typically from different
+// packages, and ResolveGroup collects them all. Group members are not
+// returned by Resolve or ResolveAll.
+func WithGroup(group string) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.Group = group
+		descriptor.appended = true
+	}
+}
+
+// RegisterGroup adds a singleton built by factory to the group of T, so
+// plugins can contribute routes, validators or jobs for the application to
+// collect:
+//
+//	inject.RegisterGroup[Route](c, "routes", NewHealthRoute)
+//	routes, err := inject.ResolveGroup[Route](c, "routes")
+func RegisterGroup[T any](container Registrar, group string, factory func(*Container) T, opts ...RegistrationOption) error {
+	return RegisterType[T](container, factory, Singleton, append(opts[:len(opts):len(opts)], WithGroup(group))...)
+}
+
+// groupResolver is implemented by resolvers that can resolve groups.
+type groupResolver interface {
+	resolveGroup(serviceType reflect.Type, group string) ([]interface{}, error)
+}
+
+// ResolveGroup resolves every member of the group of T in the order they
+// were registered. An empty group yields no members and no error. Every
+// failure is reported in the joined error.
+func ResolveGroup[T any](container Resolver, group string) ([]T, error) {
+	serviceType := reflect.TypeFor[T]()
+	r, ok := container.(groupResolver)
+	if !ok {
+		return nil, newError(ErrCodeInvalidArgument, serviceType, "resolver %T does not support groups", container)
+	}
+	instances, err := r.resolveGroup(serviceType, group)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]T, len(instances))
+	for i, instance := range instances {
+		results[i], _ = instance.(T)
+	}
+	return results, nil
+}
+
+func MustResolveGroup[T any](container Resolver, group string) []T {
+	results, err := ResolveGroup[T](container, group)
+	if err != nil {
+		var zero T
+		panic(fmt.Sprintf("failed to resolve group %q of %T: %v", group, zero, err))
+	}
+	return results
+}
+
+func (c *Container) resolveGroup(serviceType reflect.Type, group string) ([]interface{}, error) {
+	return c.resolveEach(serviceType, func(key serviceKey) bool {
+		return key.group == group
+	})
+}
+
+func (s *Scope) resolveGroup(serviceType reflect.Type, group string) ([]interface{}, error) {
+	return s.container.resolveGroup(serviceType, group)
+}
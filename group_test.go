@@ -0,0 +1,109 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveGroup(t *testing.T) {
+	container := NewContainer()
+
+	for _, value := range []string{"users", "orders"} {
+		err := RegisterGroup[TestInterface](container, "routes", func(c *Container) TestInterface {
+			return &TestImplementation{value: value}
+		})
+		if err != nil {
+			t.Fatalf("Failed to register group member: %v", err)
+		}
+	}
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "default"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	routes, err := ResolveGroup[TestInterface](container, "routes")
+	if err != nil {
+		t.Fatalf("Failed to resolve group: %v", err)
+	}
+	var values []string
+	for _, route := range routes {
+		values = append(values, route.GetValue())
+	}
+	if strings.Join(values, ",") != "users,orders" {
+		t.Errorf("Expected group members in registration order, got %v", values)
+	}
+	if routes[0] != MustResolveGroup[TestInterface](container, "routes")[0] {
+		t.Error("Group members registered with RegisterGroup should be singletons")
+	}
+
+	if MustResolve[TestInterface](container).GetValue() != "default" {
+		t.Error("Group members should not be returned by Resolve")
+	}
+	all, err := ResolveAll[TestInterface](container)
+	if err != nil || len(all) != 1 {
+		t.Errorf("Group members should not be returned by ResolveAll, got %d: %v", len(all), err)
+	}
+
+	jobs, err := ResolveGroup[TestInterface](container, "jobs")
+	if err != nil || len(jobs) != 0 {
+		t.Errorf("An empty group should resolve to no members, got %v: %v", jobs, err)
+	}
+}
+
+type routeResults struct {
+	Out
+
+	Users  TestInterface `group:"routes"`
+	Orders TestInterface `group:"routes"`
+}
+
+func TestResultStructGroup(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterConstructor(container, func() routeResults {
+		return routeResults{
+			Users:  &TestImplementation{value: "users"},
+			Orders: &TestImplementation{value: "orders"},
+		}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+
+	routes := MustResolveGroup[TestInterface](container, "routes")
+	if len(routes) != 2 || routes[0].GetValue() != "users" || routes[1].GetValue() != "orders" {
+		t.Errorf("Fields tagged group should join the group, got %v", routes)
+	}
+}
+
+type routerParams struct {
+	In
+
+	Routes []TestInterface `group:"routes"`
+}
+
+func TestParamStructGroup(t *testing.T) {
+	container := NewContainer()
+
+	for _, value := range []string{"users", "orders"} {
+		err := RegisterGroup[TestInterface](container, "routes", func(c *Container) TestInterface {
+			return &TestImplementation{value: value}
+		})
+		if err != nil {
+			t.Fatalf("Failed to register group member: %v", err)
+		}
+	}
+
+	var routes []TestInterface
+	err := container.Invoke(func(p routerParams) {
+		routes = p.Routes
+	})
+	if err != nil {
+		t.Fatalf("Failed to invoke: %v", err)
+	}
+	if len(routes) != 2 || routes[1].GetValue() != "orders" {
+		t.Errorf("Fields tagged group should receive the group members, got %v", routes)
+	}
+}
@@ -0,0 +1,65 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// HealthChecker is implemented by services that can report whether they are
+// working, such as database or cache clients.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthResult is the outcome of one service's check. Degraded services,
+// whose last instance came from a WithFallback factory, fail with the
+// primary factory's error unless their own check fails first.
+type HealthResult struct {
+	ServiceType reflect.Type
+	Degraded    bool
+	Err         error
+}
+
+// CheckHealth runs HealthCheck on every created singleton that implements
+// HealthChecker, and reports every degraded service. Singletons that have
+// not been built yet are skipped rather than constructed.
+func (c *Container) CheckHealth(ctx context.Context) []HealthResult {
+	var results []HealthResult
+	var checkers []HealthChecker
+
+	c.mu.RLock()
+	for _, descriptor := range c.sortedDescriptors() {
+		descriptor.stats.mu.Lock()
+		degradedBy := descriptor.stats.degradedBy
+		descriptor.stats.mu.Unlock()
+
+		var checker HealthChecker
+		if descriptor.Lifecycle == Singleton {
+			descriptor.mu.RLock()
+			checker, _ = descriptor.instance.(HealthChecker)
+			descriptor.mu.RUnlock()
+		}
+		if checker == nil && degradedBy == nil {
+			continue
+		}
+		result := HealthResult{ServiceType: descriptor.ServiceType}
+		if degradedBy != nil {
+			result.Degraded = true
+			result.Err = fmt.Errorf("degraded to fallback: %w", degradedBy)
+		}
+		results = append(results, result)
+		checkers = append(checkers, checker)
+	}
+	c.mu.RUnlock()
+
+	for i, checker := range checkers {
+		if checker == nil {
+			continue
+		}
+		if err := checker.HealthCheck(ctx); err != nil {
+			results[i].Err = err
+		}
+	}
+	return results
+}
@@ -0,0 +1,58 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type healthyService struct {
+	err error
+}
+
+func (s *healthyService) HealthCheck(ctx context.Context) error {
+	return s.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*healthyService](container, func(c *Container) *healthyService {
+		return &healthyService{err: errors.New("replica lag")}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if results := container.CheckHealth(context.Background()); len(results) != 0 {
+		t.Error("Singletons that were not built should not be checked")
+	}
+
+	MustResolve[*healthyService](container)
+	results := container.CheckHealth(context.Background())
+	if len(results) != 1 || results[0].Err == nil || results[0].ServiceType.String() != "*inject.healthyService" {
+		t.Errorf("Unexpected health results: %+v", results)
+	}
+}
+
+func TestCheckHealthReportsDegraded(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestInterface)(nil), func() (TestInterface, error) {
+		return nil, errors.New("redis unavailable")
+	}, WithFallback(func() TestInterface {
+		return &TestImplementation{value: "memory"}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*TestInterface)(nil)); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	results := container.CheckHealth(context.Background())
+	if len(results) != 1 || !results[0].Degraded || results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "redis unavailable") {
+		t.Errorf("Expected the degraded service to be reported unhealthy, got %+v", results)
+	}
+}
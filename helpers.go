@@ -5,87 +5,203 @@ import (
 	"reflect"
 )
 
-func MustResolve[T any](container *Container) T {
+func MustResolve[T any](container Resolver) T {
 	var zero T
-	result, err := container.Resolve((*T)(nil))
+	result, err := resolveAs[T](container)
 	if err != nil {
+		if c, ok := container.(*Container); ok {
+			c.report(reflect.TypeOf((*T)(nil)).Elem(), err, true)
+		}
 		panic(fmt.Sprintf("failed to resolve service of type %T: %v", zero, err))
 	}
-	return result.(T)
+	return result
 }
 
-func TryResolve[T any](container *Container) (T, bool) {
+func TryResolve[T any](container Resolver) (T, bool) {
+	result, err := resolveAs[T](container)
+	if err != nil {
+		return result, false
+	}
+	return result, true
+}
+
+func resolveAs[T any](container Resolver) (T, error) {
 	var zero T
-	result, err := container.Resolve((*T)(nil))
+	var result interface{}
+	var err error
+	if c, ok := container.(*Container); ok {
+		serviceType := reflect.TypeFor[T]()
+		if err := c.checkPolicy(serviceType); err != nil {
+			return zero, err
+		}
+		if typed, handled, err := resolveTransient[T](c); handled {
+			return typed, err
+		}
+		result, err = c.resolve(serviceType)
+	} else {
+		result, err = container.Resolve((*T)(nil))
+	}
 	if err != nil {
-		return zero, false
+		return zero, err
 	}
-	return result.(T), true
+	return result.(T), nil
 }
 
-func RegisterInterface[TInterface, TImplementation any](container *Container, factory func(*Container) TImplementation, lifecycle Lifecycle) error {
-	return container.Register((*TInterface)(nil), func(c *Container) TInterface {
+func RegisterInterface[TInterface, TImplementation any](container Registrar, factory func(*Container) TImplementation, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerTyped(container, (*TInterface)(nil), func(c *Container) TInterface {
 		impl := factory(c)
 		return any(impl).(TInterface)
-	}, lifecycle)
+	}, lifecycle, opts)
 }
 
-func RegisterSingletonInterface[TInterface, TImplementation any](container *Container, factory func(*Container) TImplementation) error {
-	return RegisterInterface[TInterface, TImplementation](container, factory, Singleton)
+func RegisterSingletonInterface[TInterface, TImplementation any](container Registrar, factory func(*Container) TImplementation, opts ...RegistrationOption) error {
+	return RegisterInterface[TInterface, TImplementation](container, factory, Singleton, opts...)
 }
 
-func RegisterTransientInterface[TInterface, TImplementation any](container *Container, factory func(*Container) TImplementation) error {
-	return RegisterInterface[TInterface, TImplementation](container, factory, Transient)
+func RegisterTransientInterface[TInterface, TImplementation any](container Registrar, factory func(*Container) TImplementation, opts ...RegistrationOption) error {
+	return RegisterInterface[TInterface, TImplementation](container, factory, Transient, opts...)
 }
 
-func RegisterType[T any](container *Container, factory func(*Container) T, lifecycle Lifecycle) error {
-	return container.Register((*T)(nil), factory, lifecycle)
+func RegisterType[T any](container Registrar, factory func(*Container) T, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerTyped(container, (*T)(nil), factory, lifecycle, opts)
 }
 
-func RegisterSingletonType[T any](container *Container, factory func(*Container) T) error {
-	return RegisterType[T](container, factory, Singleton)
+func RegisterSingletonType[T any](container Registrar, factory func(*Container) T, opts ...RegistrationOption) error {
+	return RegisterType[T](container, factory, Singleton, opts...)
 }
 
-func RegisterTransientType[T any](container *Container, factory func(*Container) T) error {
-	return RegisterType[T](container, factory, Transient)
+func RegisterTransientType[T any](container Registrar, factory func(*Container) T, opts ...RegistrationOption) error {
+	return RegisterType[T](container, factory, Transient, opts...)
 }
 
-func RegisterValue[T any](container *Container, value T) error {
-	return container.RegisterSingleton((*T)(nil), func() T {
+func RegisterValue[T any](container Registrar, value T, opts ...RegistrationOption) error {
+	return registerTyped(container, (*T)(nil), func(*Container) T {
 		return value
+	}, Singleton, opts)
+}
+
+// RegisterValueAs registers an already built value under TInterface, so
+// existing clients and config structs bind to an interface without a
+// factory wrapper. Pass WithName to register it under a name:
+//
+//	inject.RegisterValueAs[Cache](c, redisClient, inject.WithName("sessions"))
+//
+// It fails with TYPE_MISMATCH if value does not implement TInterface.
+func RegisterValueAs[TInterface, TImplementation any](container Registrar, value TImplementation, opts ...RegistrationOption) error {
+	service, ok := any(value).(TInterface)
+	if !ok {
+		serviceType := reflect.TypeFor[TInterface]()
+		return newError(ErrCodeTypeMismatch, serviceType, "value of type %T does not implement %s", value, serviceType.String())
+	}
+	return RegisterValue[TInterface](container, service, opts...)
+}
+
+// RegisterAdapter exposes a registered TFrom as TTo, e.g. a read-only
+// interface over a richer service. Resolving TTo resolves TFrom and converts
+// it with adapt on every call, so the identity of the result follows
+// TFrom's lifecycle.
+func RegisterAdapter[TFrom, TTo any](container Registrar, adapt func(TFrom) TTo, opts ...RegistrationOption) error {
+	factory := func(from TFrom) TTo {
+		return adapt(from)
+	}
+	r, ok := container.(typedRegistrar)
+	if !ok {
+		return container.Register((*TTo)(nil), factory, Transient, opts...)
+	}
+
+	fromType := reflect.TypeFor[TFrom]()
+	return r.register((*TTo)(nil), factory, Transient, func(c *Container) (interface{}, error) {
+		from, err := c.resolveType(fromType)
+		if err != nil {
+			return nil, dependencyError(fromType, err)
+		}
+		value, _ := from.(TFrom)
+		return adapt(value), nil
+	}, opts)
+}
+
+// typedRegistrar is implemented by registrars that accept a typed create
+// function alongside the factory, so registrations skip reflective calls.
+type typedRegistrar interface {
+	register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) error
+}
+
+func registerTyped[T any](container Registrar, serviceType interface{}, factory func(*Container) T, lifecycle Lifecycle, opts []RegistrationOption) error {
+	r, ok := container.(typedRegistrar)
+	if !ok {
+		return container.Register(serviceType, factory, lifecycle, opts...)
+	}
+	opts = append(opts[:len(opts):len(opts)], func(descriptor *ServiceDescriptor) {
+		descriptor.typed = &typedFactory[T]{factory: factory}
 	})
+	return r.register(serviceType, factory, lifecycle, func(c *Container) (interface{}, error) {
+		return factory(c), nil
+	}, opts)
 }
 
-func (c *Container) RegisterFunc(factory interface{}, lifecycle Lifecycle) error {
+func (c *Container) RegisterFunc(factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerFunc(c, factory, lifecycle, opts)
+}
+
+// RegisterConstructor registers constructor under its first return type, or
+// each field of it if it is a result object, see Out. Each of its
+// parameters is resolved from the container when it is called, so existing
+// constructors need no wrapper:
+//
+//	inject.RegisterConstructor(c, NewUserService, inject.Singleton)
+func RegisterConstructor(container Registrar, constructor interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerFunc(container, constructor, lifecycle, opts)
+}
+
+// registerFunc registers factory under its first return type.
+func registerFunc(registrar Registrar, factory interface{}, lifecycle Lifecycle, opts []RegistrationOption) error {
 	factoryType := reflect.TypeOf(factory)
-	if factoryType.Kind() != reflect.Func {
-		return fmt.Errorf("factory must be a function")
+	if factoryType == nil || factoryType.Kind() != reflect.Func {
+		return newError(ErrCodeInvalidFactory, nil, "factory must be a function")
 	}
 
 	if factoryType.NumOut() == 0 {
-		return fmt.Errorf("factory function must return at least one value")
+		return newError(ErrCodeInvalidFactory, nil, "factory function must return at least one value")
 	}
 
+	// Register with the exact return type
 	returnType := factoryType.Out(0)
-
-	if returnType.Kind() == reflect.Interface {
-		return c.Register(reflect.New(returnType).Interface(), factory, lifecycle)
+	if isResultStruct(returnType) {
+		return registerResults(registrar, returnType, factory, lifecycle, opts)
 	}
+	return registrar.Register(reflect.New(returnType).Interface(), factory, lifecycle, opts...)
+}
 
-	// Register with the exact return type
-	return c.Register(reflect.New(returnType).Interface(), factory, lifecycle)
+// RegisterDynamic registers factory under a type only known at runtime, such
+// as one produced by a plugin or generated code. The factory is validated
+// exactly as Register validates it.
+func (c *Container) RegisterDynamic(serviceType reflect.Type, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	if serviceType == nil {
+		return newError(ErrCodeInvalidArgument, nil, "service type must not be nil")
+	}
+	return c.Register(reflect.New(serviceType).Interface(), factory, lifecycle, opts...)
 }
 
 func (c *Container) Has(serviceType interface{}) bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	defer c.readLock()()
+	return c.has(serviceTypeOf(serviceType))
+}
 
-	sType := reflect.TypeOf(serviceType)
-	if sType.Kind() == reflect.Ptr {
-		sType = sType.Elem()
+// has reports whether serviceType can be resolved. Callers must hold c.mu.
+func (c *Container) has(serviceType reflect.Type) bool {
+	if _, exists := c.services[serviceKey{serviceType: serviceType}]; exists {
+		return true
 	}
-
-	_, exists := c.services[sType]
+	if _, exists := c.channelKey(serviceType); exists {
+		return true
+	}
+	if target, ok := lazyKey(serviceType); ok {
+		return c.has(target)
+	}
+	if _, exists := c.collectionKey(serviceType); exists {
+		return true
+	}
+	_, exists := c.genericKeyRegistered(serviceType)
 	return exists
 }
 
@@ -94,8 +210,56 @@ func (c *Container) GetServiceTypes() []reflect.Type {
 	defer c.mu.RUnlock()
 
 	types := make([]reflect.Type, 0, len(c.services))
-	for serviceType := range c.services {
-		types = append(types, serviceType)
+	for _, descriptor := range c.sortedDescriptors() {
+		types = append(types, descriptor.ServiceType)
 	}
 	return types
 }
+
+func resolveArguments(resolver Resolver, fnType reflect.Type) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		arg, err := resolveValue(resolver, fnType.In(i))
+		if err != nil {
+			return nil, dependencyError(fnType.In(i), err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+func invokeFactory(resolver Resolver, factory interface{}) (interface{}, error) {
+	factoryValue := reflect.ValueOf(factory)
+
+	args, err := resolveArguments(resolver, factoryValue.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	results := factoryValue.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, results[1].Interface().(error)
+	}
+	return results[0].Interface(), nil
+}
+
+func resolveValue(resolver Resolver, serviceType reflect.Type) (reflect.Value, error) {
+	if container, ok := resolver.(*Container); ok && serviceType == reflect.TypeOf((*Container)(nil)) {
+		return reflect.ValueOf(container), nil
+	}
+	if container, ok := resolver.(*Container); ok && serviceType == contextType {
+		return reflect.ValueOf(container.context()), nil
+	}
+	if container, ok := resolver.(*Container); ok && isParamStruct(serviceType) {
+		return container.resolveParams(serviceType)
+	}
+
+	service, err := resolver.Resolve(reflect.New(serviceType).Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if service == nil {
+		return reflect.Zero(serviceType), nil
+	}
+	return reflect.ValueOf(service), nil
+}
@@ -1,6 +1,9 @@
 package inject
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -218,6 +221,67 @@ func TestRegisterFunc(t *testing.T) {
 	}
 }
 
+func TestRegisterDynamic(t *testing.T) {
+	container := NewContainer()
+
+	serviceType := reflect.TypeFor[*TestImplementation]()
+	factory := reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{serviceType}, false), func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf(&TestImplementation{value: "dynamic"})}
+	}).Interface()
+
+	err := container.RegisterDynamic(serviceType, factory, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register dynamic service: %v", err)
+	}
+
+	service, err := container.ResolveByType(serviceType)
+	if err != nil {
+		t.Fatalf("Failed to resolve dynamic service: %v", err)
+	}
+	if service.(*TestImplementation).GetValue() != "dynamic" {
+		t.Error("RegisterDynamic should register the factory under the given type")
+	}
+
+	err = container.RegisterDynamic(reflect.TypeFor[TestInterface](), func() *TestRepository {
+		return &TestRepository{}
+	}, Transient)
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH, got %v", err)
+	}
+
+	err = container.RegisterDynamic(nil, factory, Transient)
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a nil type, got %v", err)
+	}
+}
+
+func TestRegisterConstructor(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterConstructor(container, func() TestInterface {
+		return &TestImplementation{value: "constructed"}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+	err = RegisterConstructor(container, func(dep TestInterface, c *Container) (*TestService, error) {
+		return &TestService{dependency: dep}, nil
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+
+	service := MustResolve[*TestService](container)
+	if service.GetDependency() != MustResolve[TestInterface](container) {
+		t.Error("Expected constructor parameters to be resolved from the container")
+	}
+
+	err = RegisterConstructor(container, "not a function", Transient)
+	if ErrorCodeOf(err) != ErrCodeInvalidFactory {
+		t.Errorf("Expected INVALID_FACTORY, got %v", err)
+	}
+}
+
 func TestRegisterFuncWithInterface(t *testing.T) {
 	container := NewContainer()
 
@@ -238,3 +302,128 @@ func TestRegisterFuncWithInterface(t *testing.T) {
 		t.Error("RegisterFunc with interface should work correctly")
 	}
 }
+
+type fakeResolver struct {
+	services map[string]interface{}
+}
+
+func (f *fakeResolver) Resolve(serviceType interface{}) (interface{}, error) {
+	service, ok := f.services[serviceTypeOf(serviceType).String()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return service, nil
+}
+
+func (f *fakeResolver) Has(serviceType interface{}) bool {
+	_, ok := f.services[serviceTypeOf(serviceType).String()]
+	return ok
+}
+
+func TestHelpersAcceptResolver(t *testing.T) {
+	fake := &fakeResolver{services: map[string]interface{}{
+		"inject.TestInterface": &TestImplementation{value: "fake"},
+	}}
+
+	service := MustResolve[TestInterface](fake)
+	if service.GetValue() != "fake" {
+		t.Error("MustResolve should resolve through any Resolver")
+	}
+
+	if _, ok := TryResolve[*TestImplementation](fake); ok {
+		t.Error("TryResolve should return false when the Resolver fails")
+	}
+}
+
+func TestHelpersWithCompositeResolver(t *testing.T) {
+	host := NewContainer()
+
+	err := RegisterSingletonType[*TestImplementation](host, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "host"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	service := MustResolve[*TestImplementation](NewCompositeResolver(NewContainer(), host))
+	if service.GetValue() != "host" {
+		t.Error("MustResolve should work with a CompositeResolver")
+	}
+}
+
+type ReadOnlyRepository interface {
+	Get(key string) string
+}
+
+type readOnlyView struct {
+	repo *TestRepository
+}
+
+func (v readOnlyView) Get(key string) string {
+	return v.repo.data[key]
+}
+
+func TestRegisterAdapter(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{data: map[string]string{"answer": "42"}}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = RegisterAdapter(container, func(repo *TestRepository) ReadOnlyRepository {
+		return readOnlyView{repo: repo}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register adapter: %v", err)
+	}
+
+	view := MustResolve[ReadOnlyRepository](container)
+	if view.Get("answer") != "42" {
+		t.Error("Adapter should wrap the registered service")
+	}
+	MustResolve[*TestRepository](container).data["answer"] = "43"
+	if MustResolve[ReadOnlyRepository](container).Get("answer") != "43" {
+		t.Error("Adapter should follow the lifecycle of the adapted service")
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Adapter dependency should be visible to Validate: %v", err)
+	}
+
+	err = RegisterAdapter(container, func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register adapter: %v", err)
+	}
+	_, err = container.Resolve((**TestService)(nil))
+	if ErrorCodeOf(err) != ErrCodeNotRegistered || !strings.Contains(err.Error(), "failed to resolve dependency inject.TestInterface") {
+		t.Errorf("Expected NOT_REGISTERED for a missing adapted service, got %v", err)
+	}
+}
+
+func TestRegisterValueAs(t *testing.T) {
+	container := NewContainer()
+
+	primary := &TestImplementation{value: "primary"}
+	backup := &TestImplementation{value: "backup"}
+	if err := RegisterValueAs[TestInterface](container, primary); err != nil {
+		t.Fatalf("Failed to register value: %v", err)
+	}
+	if err := RegisterValueAs[TestInterface](container, backup, WithName("backup")); err != nil {
+		t.Fatalf("Failed to register named value: %v", err)
+	}
+
+	if MustResolve[TestInterface](container) != TestInterface(primary) {
+		t.Error("RegisterValueAs should bind the value to the interface")
+	}
+	if MustResolveNamed[TestInterface](container, "backup") != TestInterface(backup) {
+		t.Error("RegisterValueAs should honour WithName")
+	}
+
+	err := RegisterValueAs[TestInterface](container, &TestRepository{})
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH for a value that does not implement the interface, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package inject
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+func Handler(resolver Resolver, constructor interface{}) (http.Handler, error) {
+	if err := validateHandlerConstructor(constructor); err != nil {
+		return nil, err
+	}
+	return buildHandler(resolver, constructor)
+}
+
+// ScopedHandler is like Handler, but builds the handler for every request
+// within the request's scope, so its constructor can take Scoped services.
+// Requests use the scope opened by HTTPMiddleware, or a scope of their own
+// that is closed once the handler returns. If the handler cannot be built,
+// the request fails with 500 and the error goes to the container's error
+// reporter.
+func ScopedHandler(container *Container, constructor interface{}) (http.Handler, error) {
+	if err := validateHandlerConstructor(constructor); err != nil {
+		return nil, err
+	}
+	handlerType := reflect.TypeOf(constructor).Out(0)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := ScopeFromContext(r.Context())
+		if !ok {
+			scope = container.NewScopeWithContext(r.Context())
+			defer scope.close(func(serviceType reflect.Type, err error) {
+				container.report(serviceType, err, false)
+			})
+			r = r.WithContext(scope.Context())
+		}
+		handler, err := buildHandler(scope.Container(), constructor)
+		if err != nil {
+			container.report(handlerType, err, false)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}), nil
+}
+
+func validateHandlerConstructor(constructor interface{}) error {
+	ctorType := reflect.TypeOf(constructor)
+	if ctorType == nil || ctorType.Kind() != reflect.Func {
+		return newError(ErrCodeInvalidFactory, nil, "handler constructor must be a function")
+	}
+
+	if err := validateFactoryResults(ctorType); err != nil {
+		return err
+	}
+
+	handlerInterface := reflect.TypeOf((*http.Handler)(nil)).Elem()
+	if !ctorType.Out(0).Implements(handlerInterface) {
+		return newError(ErrCodeTypeMismatch, ctorType.Out(0), "handler constructor return type %s does not implement http.Handler", ctorType.Out(0).String())
+	}
+	return nil
+}
+
+func buildHandler(resolver Resolver, constructor interface{}) (http.Handler, error) {
+	result, err := invokeFactory(resolver, constructor)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, ok := result.(http.Handler)
+	if !ok || isNilHandler(handler) {
+		return nil, newError(ErrCodeFactoryError, nil, "handler constructor returned a nil handler")
+	}
+	return handler, nil
+}
+
+// isNilHandler also catches nil pointers and funcs, such as a nil
+// http.HandlerFunc, which make a non-nil http.Handler.
+func isNilHandler(handler http.Handler) bool {
+	if handler == nil {
+		return true
+	}
+	value := reflect.ValueOf(handler)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Func, reflect.Map, reflect.Chan, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+func MustHandler(resolver Resolver, constructor interface{}) http.Handler {
+	handler, err := Handler(resolver, constructor)
+	if err != nil {
+		panic(fmt.Sprintf("failed to construct handler: %v", err))
+	}
+	return handler
+}
+
+// HTTPMiddleware opens a Scope for each request and closes it once the
+// handler returns. Handlers find the scope with ScopeFromContext on the
+// request's context. Failures to close scoped instances go to the
+// container's error reporter.
+func HTTPMiddleware(container *Container) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := container.NewScopeWithContext(r.Context())
+			defer scope.close(func(serviceType reflect.Type, err error) {
+				container.report(serviceType, err, false)
+			})
+			next.ServeHTTP(w, r.WithContext(scope.Context()))
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package inject
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestHandlerInjectsDependencies(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "handled"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	calls := 0
+	handler, err := Handler(container, func(svc TestInterface) http.Handler {
+		calls++
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(svc.GetValue()))
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to construct handler: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Body.String() != "handled" {
+			t.Errorf("Expected 'handled', got '%s'", recorder.Body.String())
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Handler constructor should run once, ran %d times", calls)
+	}
+}
+
+func TestHandlerAcceptsHandlerFunc(t *testing.T) {
+	container := NewContainer()
+
+	handler, err := Handler(container, func(c *Container) (http.HandlerFunc, error) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to construct handler: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, recorder.Code)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	container := NewContainer()
+
+	if _, err := Handler(container, "not a function"); err == nil {
+		t.Error("Expected error for non-function constructor")
+	}
+
+	if _, err := Handler(container, func() string { return "" }); err == nil {
+		t.Error("Expected error when constructor does not return an http.Handler")
+	}
+
+	_, err := Handler(container, func(svc TestInterface) http.Handler { return nil })
+	if err == nil {
+		t.Error("Expected error when a dependency is not registered")
+	}
+
+	_, err = Handler(container, func() (http.Handler, error) {
+		return nil, errors.New("constructor error")
+	})
+	if err == nil || err.Error() != "constructor error" {
+		t.Errorf("Expected constructor error, got %v", err)
+	}
+
+	_, err = Handler(container, func() http.HandlerFunc { return nil })
+	if ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Expected FACTORY_ERROR for a nil handler func, got %v", err)
+	}
+}
+
+func TestMustHandlerPanic(t *testing.T) {
+	container := NewContainer()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustHandler should panic when a dependency is missing")
+		}
+	}()
+
+	MustHandler(container, func(svc TestInterface) http.Handler { return nil })
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	var requests int
+	err := container.Register((**requestContext)(nil), func() *requestContext {
+		requests++
+		return &requestContext{id: requests, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	if err := RegisterType[failingCloser](container, func(c *Container) failingCloser { return failingCloser{} }, Scoped); err != nil {
+		t.Fatalf("Failed to register closer: %v", err)
+	}
+
+	var reports []ErrorReport
+	container.SetErrorReporter(func(report ErrorReport) {
+		reports = append(reports, report)
+	})
+
+	handler := HTTPMiddleware(container)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := ScopeFromContext(r.Context())
+		if !ok {
+			t.Fatal("Expected the request context to carry a scope")
+		}
+		first := MustResolve[*requestContext](scope)
+		second := MustResolve[*requestContext](scope)
+		if first != second {
+			t.Error("Expected one scoped instance per request")
+		}
+		MustResolve[failingCloser](scope)
+		w.Write([]byte(strconv.Itoa(first.id)))
+	}))
+
+	for i := 1; i <= 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Body.String() != strconv.Itoa(i) {
+			t.Errorf("Expected request %d to get its own instance, got %s", i, recorder.Body.String())
+		}
+	}
+
+	if len(closed) != 2 {
+		t.Errorf("Expected each request's scope to be closed, got %v", closed)
+	}
+	if len(reports) != 2 || !errors.Is(reports[0].Err, errCloseFailed) {
+		t.Errorf("Expected close failures to be reported, got %v", reports)
+	}
+}
+
+func TestScopedHandler(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	var requests int
+	err := container.Register((**requestContext)(nil), func() *requestContext {
+		requests++
+		return &requestContext{id: requests, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+
+	handler, err := ScopedHandler(container, func(request *requestContext) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(strconv.Itoa(request.id)))
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to construct handler: %v", err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Body.String() != strconv.Itoa(i) {
+			t.Errorf("Expected request %d to build its own handler, got %s", i, recorder.Body.String())
+		}
+	}
+	if len(closed) != 2 {
+		t.Errorf("Expected the scope of each request to be closed, got %v", closed)
+	}
+
+	recorder := httptest.NewRecorder()
+	HTTPMiddleware(container)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Body.String() != "3" || len(closed) != 3 {
+		t.Errorf("Expected the handler to use the middleware's scope, got %s", recorder.Body.String())
+	}
+}
+
+func TestScopedHandlerErrors(t *testing.T) {
+	container := NewContainer()
+
+	if _, err := ScopedHandler(container, func() string { return "" }); ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH, got %v", err)
+	}
+
+	var reports []ErrorReport
+	container.SetErrorReporter(func(report ErrorReport) {
+		reports = append(reports, report)
+	})
+	handler, err := ScopedHandler(container, func(svc TestInterface) http.Handler { return nil })
+	if err != nil {
+		t.Fatalf("Failed to construct handler: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	if len(reports) != 1 {
+		t.Errorf("Expected the failure to be reported, got %v", reports)
+	}
+}
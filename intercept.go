@@ -0,0 +1,125 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// Invocation is a method call on an intercepted service.
+type Invocation struct {
+	Method string
+	Args   []interface{}
+	// Target is the service being intercepted
+	Target  interface{}
+	proceed func() []interface{}
+}
+
+// Proceed passes the call on to the next interceptor, or to the target, and
+// returns its results.
+func (inv *Invocation) Proceed() []interface{} {
+	return inv.proceed()
+}
+
+// Interceptor handles a method call. It can inspect or change the arguments,
+// call Proceed and wrap its results, or return results of its own without
+// calling the target at all.
+type Interceptor func(inv *Invocation) []interface{}
+
+// ProxyHandler runs a method call through the interceptors and returns the
+// results as the method's return values in order.
+type ProxyHandler func(method string, args ...interface{}) []interface{}
+
+// Intercept routes every method call on the interface service T through the
+// interceptors, the first one outermost. Go cannot implement an interface at
+// run time, so proxy adapts a ProxyHandler to T with one line per method:
+//
+//	type userServiceProxy struct{ call inject.ProxyHandler }
+//
+//	func (p userServiceProxy) GetUser(id int) (*User, error) {
+//	    out := p.call("GetUser", id)
+//	    return inject.ProxyResult[*User](out, 0), inject.ProxyResult[error](out, 1)
+//	}
+//
+//	inject.Intercept[UserService](c, func(call inject.ProxyHandler) UserService {
+//	    return userServiceProxy{call}
+//	}, logCalls, retryOnTimeout)
+//
+// Interception is applied with Decorate, so it follows the same rules.
+func Intercept[T any](container *Container, proxy func(ProxyHandler) T, interceptors ...Interceptor) error {
+	serviceType := reflect.TypeFor[T]()
+	if serviceType.Kind() != reflect.Interface {
+		return newError(ErrCodeInvalidArgument, serviceType, "only interface services can be intercepted, %s is not an interface", serviceType.String())
+	}
+	if proxy == nil {
+		return newError(ErrCodeInvalidFactory, serviceType, "proxy must not be nil")
+	}
+
+	return Decorate[T](container, func(inner T, c *Container) T {
+		target := reflect.ValueOf(inner)
+		return proxy(func(method string, args ...interface{}) []interface{} {
+			return intercept(target, method, args, interceptors)
+		})
+	})
+}
+
+func intercept(target reflect.Value, method string, args []interface{}, interceptors []Interceptor) []interface{} {
+	call := func() []interface{} {
+		return callMethod(target, method, args)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], call
+		call = func() []interface{} {
+			return interceptor(&Invocation{
+				Method:  method,
+				Args:    args,
+				Target:  target.Interface(),
+				proceed: next,
+			})
+		}
+	}
+	return call()
+}
+
+func callMethod(target reflect.Value, name string, args []interface{}) []interface{} {
+	method := target.MethodByName(name)
+	if !method.IsValid() {
+		panic(newError(ErrCodeInvalidArgument, target.Type(), "%s has no method %s", target.Type().String(), name))
+	}
+
+	methodType := method.Type()
+	// A variadic method's arguments may come spread out or as one slice
+	asSlice := false
+	if last := methodType.NumIn() - 1; methodType.IsVariadic() && len(args) == last+1 {
+		asSlice = args[last] == nil || reflect.TypeOf(args[last]).AssignableTo(methodType.In(last))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		if arg != nil {
+			in[i] = reflect.ValueOf(arg)
+			continue
+		}
+		argType := methodType.In(min(i, methodType.NumIn()-1))
+		if methodType.IsVariadic() && i >= methodType.NumIn()-1 && !asSlice {
+			argType = argType.Elem()
+		}
+		in[i] = reflect.Zero(argType)
+	}
+
+	var out []reflect.Value
+	if asSlice {
+		out = method.CallSlice(in)
+	} else {
+		out = method.Call(in)
+	}
+	results := make([]interface{}, len(out))
+	for i, value := range out {
+		results[i] = value.Interface()
+	}
+	return results
+}
+
+// ProxyResult returns result i of an intercepted call as T, or the zero T
+// when it is nil.
+func ProxyResult[T any](results []interface{}, i int) T {
+	value, _ := results[i].(T)
+	return value
+}
@@ -0,0 +1,93 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type greeter interface {
+	Greet(name string) (string, error)
+	Join(sep string, parts ...string) string
+}
+
+type englishGreeter struct{}
+
+func (englishGreeter) Greet(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("name required")
+	}
+	return "hello " + name, nil
+}
+
+func (englishGreeter) Join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+type greeterProxy struct {
+	call ProxyHandler
+}
+
+func (p greeterProxy) Greet(name string) (string, error) {
+	out := p.call("Greet", name)
+	return ProxyResult[string](out, 0), ProxyResult[error](out, 1)
+}
+
+func (p greeterProxy) Join(sep string, parts ...string) string {
+	return ProxyResult[string](p.call("Join", sep, parts), 0)
+}
+
+func TestIntercept(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[greeter, englishGreeter](container, func(c *Container) englishGreeter {
+		return englishGreeter{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register greeter: %v", err)
+	}
+
+	var calls []string
+	logCalls := func(inv *Invocation) []interface{} {
+		calls = append(calls, inv.Method)
+		return inv.Proceed()
+	}
+	shout := func(inv *Invocation) []interface{} {
+		out := inv.Proceed()
+		if s, ok := out[0].(string); ok {
+			out[0] = strings.ToUpper(s)
+		}
+		return out
+	}
+	defaultName := func(inv *Invocation) []interface{} {
+		if inv.Method == "Greet" && inv.Args[0] == "" {
+			return []interface{}{"hello stranger", nil}
+		}
+		return inv.Proceed()
+	}
+	err = Intercept[greeter](container, func(call ProxyHandler) greeter {
+		return greeterProxy{call}
+	}, logCalls, shout, defaultName)
+	if err != nil {
+		t.Fatalf("Failed to intercept greeter: %v", err)
+	}
+
+	g := MustResolve[greeter](container)
+	if greeting, err := g.Greet("ada"); err != nil || greeting != "HELLO ADA" {
+		t.Errorf("Expected 'HELLO ADA', got '%s' (%v)", greeting, err)
+	}
+	if greeting, err := g.Greet(""); err != nil || greeting != "HELLO STRANGER" {
+		t.Errorf("Expected an interceptor to short-circuit the call, got '%s' (%v)", greeting, err)
+	}
+	if joined := g.Join("-", "a", "b"); joined != "A-B" {
+		t.Errorf("Expected variadic arguments to reach the target, got '%s'", joined)
+	}
+	if strings.Join(calls, ",") != "Greet,Greet,Join" {
+		t.Errorf("Expected every call to be intercepted, got %v", calls)
+	}
+
+	err = Intercept[*TestImplementation](container, func(call ProxyHandler) *TestImplementation { return nil })
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected %s for a concrete service, got %v", ErrCodeInvalidArgument, err)
+	}
+}
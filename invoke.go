@@ -0,0 +1,67 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// Invoke calls fn with each of its parameters resolved from the container,
+// the natural entry point for main:
+//
+//	err := c.Invoke(func(server *http.Server, log Logger) error { ... })
+//
+// If fn's last result is a non-nil error, Invoke returns it. Other results
+// are discarded.
+func (c *Container) Invoke(fn interface{}) error {
+	fnValue := reflect.ValueOf(fn)
+	if !fnValue.IsValid() || fnValue.Kind() != reflect.Func {
+		return newError(ErrCodeInvalidArgument, nil, "Invoke requires a function, got %T", fn)
+	}
+	if c.noReflection {
+		return newError(ErrCodeReflectionDisabled, fnValue.Type(), "Invoke is disabled on containers created with WithNoReflection; use Invoke1 or Invoke2")
+	}
+
+	args, err := resolveArguments(c, fnValue.Type())
+	if err != nil {
+		return err
+	}
+	results := fnValue.Call(args)
+	if len(results) == 0 {
+		return nil
+	}
+	if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
+		return err
+	}
+	return nil
+}
+
+// Invoke calls fn with its parameters resolved within the scope.
+func (s *Scope) Invoke(fn interface{}) error {
+	return s.container.Invoke(fn)
+}
+
+// Invoke1 calls fn with T resolved from the container, without reflection.
+func Invoke1[T any](container Resolver, fn func(T) error) error {
+	value, err := resolveAs[T](container)
+	if err != nil {
+		return invokeError[T](err)
+	}
+	return fn(value)
+}
+
+// Invoke2 calls fn with T1 and T2 resolved from the container, without
+// reflection.
+func Invoke2[T1, T2 any](container Resolver, fn func(T1, T2) error) error {
+	first, err := resolveAs[T1](container)
+	if err != nil {
+		return invokeError[T1](err)
+	}
+	second, err := resolveAs[T2](container)
+	if err != nil {
+		return invokeError[T2](err)
+	}
+	return fn(first, second)
+}
+
+func invokeError[T any](err error) error {
+	return dependencyError(reflect.TypeFor[T](), err)
+}
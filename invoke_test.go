@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInvoke(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "invoked"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	var got string
+	err = container.Invoke(func(dep TestInterface, c *Container) {
+		got = dep.GetValue()
+	})
+	if err != nil {
+		t.Fatalf("Failed to invoke: %v", err)
+	}
+	if got != "invoked" {
+		t.Errorf("Expected the resolved dependency, got %q", got)
+	}
+
+	errFailed := errors.New("run failed")
+	err = container.Invoke(func(dep TestInterface) (int, error) {
+		return 0, errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Errorf("Expected the function's error, got %v", err)
+	}
+
+	err = container.Invoke(func(repo *TestRepository) error {
+		t.Error("Invoke should not call the function when a dependency is missing")
+		return nil
+	})
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	if err := container.Invoke("main"); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+}
+
+func TestInvokeGenericHelpers(t *testing.T) {
+	container := NewContainer(WithNoReflection())
+
+	err := RegisterValue[TestInterface](container, &TestImplementation{value: "typed"})
+	if err != nil {
+		t.Fatalf("Failed to register value: %v", err)
+	}
+	err = RegisterValue(container, &TestRepository{data: map[string]string{"key": "value"}})
+	if err != nil {
+		t.Fatalf("Failed to register value: %v", err)
+	}
+
+	err = Invoke2(container, func(dep TestInterface, repo *TestRepository) error {
+		if dep.GetValue() != "typed" || repo.Get("key") != "value" {
+			t.Error("Expected both dependencies to be resolved")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to invoke: %v", err)
+	}
+
+	err = Invoke1(container, func(*TestService) error { return nil })
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	if err := container.Invoke(func(TestInterface) {}); ErrorCodeOf(err) != ErrCodeReflectionDisabled {
+		t.Errorf("Expected REFLECTION_DISABLED, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// RegisterKeyed registers T with the Keyed lifecycle: factory receives a key
+// and the container caches one instance per key, e.g. a client per region
+// built from one template:
+//
+//	inject.RegisterKeyed(c, func(c *inject.Container, region string) (*s3.Client, error) { ... })
+//	client, err := inject.ResolveKeyed[*s3.Client](c, "eu-west-1")
+//
+// A failed construction is not cached, so the next resolution of the key
+// tries again.
+func RegisterKeyed[T any](container Registrar, factory func(c *Container, key string) (T, error), opts ...RegistrationOption) error {
+	r, ok := container.(typedRegistrar)
+	if !ok {
+		return newError(ErrCodeInvalidArgument, reflect.TypeFor[T](), "keyed services can only be registered on a container or transaction")
+	}
+	opts = append(opts[:len(opts):len(opts)], func(descriptor *ServiceDescriptor) {
+		descriptor.keyed = func(c *Container, key string) (interface{}, error) {
+			return factory(c, key)
+		}
+	})
+	// Factory stands in for the keyed factory in graphs and reports, which
+	// only know factories that take services
+	placeholder := func(*Container) (T, error) {
+		var zero T
+		return zero, fmt.Errorf("keyed service %s requires a key", reflect.TypeFor[T]().String())
+	}
+	return r.register((*T)(nil), placeholder, Keyed, func(c *Container) (interface{}, error) {
+		return placeholder(c)
+	}, opts)
+}
+
+// ResolveKeyed returns the instance of the Keyed service T for key, building
+// it on first use.
+func ResolveKeyed[T any](c *Container, key string) (T, error) {
+	var zero T
+	serviceType := reflect.TypeFor[T]()
+	if err := c.checkPolicy(serviceType); err != nil {
+		return zero, err
+	}
+
+	defer c.readLock()()
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType}]
+	if !exists {
+		return zero, c.notRegisteredError(serviceType)
+	}
+	if descriptor.Lifecycle != Keyed {
+		return zero, newError(ErrCodeInvalidArgument, serviceType, "service of type %s is not keyed", serviceType.String())
+	}
+	c.recordDependency(descriptor)
+	if c.closed.Load() {
+		return zero, errContainerClosed(descriptor.ServiceType)
+	}
+	if err := c.checkCycle(descriptor, key); err != nil {
+		return zero, err
+	}
+
+	start := time.Now()
+	instance, cached, err := c.resolveKeyed(descriptor, key)
+	if hook := c.resolveHook.Load(); hook != nil {
+		(*hook)(c.resolveEvent(descriptor, cached, start, err))
+	}
+	if err != nil {
+		return zero, err
+	}
+	value, ok := instance.(T)
+	if !ok && instance != nil {
+		return zero, newError(ErrCodeTypeMismatch, serviceType, "keyed instance of type %T is not a %s", instance, serviceType.String())
+	}
+	return value, nil
+}
+
+func (c *Container) resolveKeyed(descriptor *ServiceDescriptor, key string) (interface{}, bool, error) {
+	descriptor.mu.Lock()
+	if f, ok := descriptor.keyedInstances[key]; ok {
+		descriptor.mu.Unlock()
+		select {
+		case <-f.done:
+		default:
+			start := time.Now()
+			<-f.done
+			descriptor.stats.recordWait(time.Since(start))
+		}
+		return f.instance, true, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	if descriptor.keyedInstances == nil {
+		descriptor.keyedInstances = make(map[string]*flight)
+	}
+	descriptor.keyedInstances[key] = f
+	descriptor.mu.Unlock()
+
+	f.instance, f.err = c.constructKeyed(descriptor, key)
+
+	descriptor.mu.Lock()
+	closed := c.closed.Load()
+	if f.err != nil || closed {
+		delete(descriptor.keyedInstances, key)
+	} else {
+		f.built = c.builds.Add(1)
+	}
+	descriptor.mu.Unlock()
+	if f.err == nil && closed {
+		f.err = disposeLate(descriptor.ServiceType, f.instance)
+		f.instance = nil
+	}
+	close(f.done)
+	return f.instance, false, f.err
+}
+
+func (c *Container) constructKeyed(descriptor *ServiceDescriptor, key string) (interface{}, error) {
+	child, err := c.enter(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	defer child.frame.done.Store(true)
+	child.frame.key = key
+
+	instance, err := child.callFactory(descriptor, descriptor.Factory, func(c *Container) (interface{}, error) {
+		return descriptor.keyed(c, key)
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	return child.decorate(descriptor, instance)
+}
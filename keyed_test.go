@@ -0,0 +1,117 @@
+package inject
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type regionClient struct {
+	region string
+}
+
+func TestResolveKeyed(t *testing.T) {
+	container := NewContainer()
+
+	var calls atomic.Int32
+	err := RegisterKeyed(container, func(c *Container, region string) (*regionClient, error) {
+		calls.Add(1)
+		return &regionClient{region: region}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register keyed service: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	clients := make([]*regionClient, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i], _ = ResolveKeyed[*regionClient](container, "eu-west-1")
+		}()
+	}
+	wg.Wait()
+	for _, client := range clients {
+		if client != clients[0] || client.region != "eu-west-1" {
+			t.Fatal("Expected one shared instance for a key")
+		}
+	}
+
+	other, err := ResolveKeyed[*regionClient](container, "us-east-1")
+	if err != nil {
+		t.Fatalf("Failed to resolve keyed service: %v", err)
+	}
+	if other == clients[0] || other.region != "us-east-1" {
+		t.Error("Expected a separate instance per key")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected the factory to run once per key, ran %d times", calls.Load())
+	}
+}
+
+func TestResolveKeyedRetriesFailures(t *testing.T) {
+	container := NewContainer()
+
+	var calls atomic.Int32
+	err := RegisterKeyed(container, func(c *Container, region string) (*regionClient, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("region unavailable")
+		}
+		return &regionClient{region: region}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register keyed service: %v", err)
+	}
+
+	_, err = ResolveKeyed[*regionClient](container, "eu-west-1")
+	if ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Expected FACTORY_ERROR, got %v", err)
+	}
+	if _, err := ResolveKeyed[*regionClient](container, "eu-west-1"); err != nil {
+		t.Errorf("Expected a failed key to be built again, got %v", err)
+	}
+}
+
+func TestKeyedLifecycleErrors(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterKeyed(container, func(c *Container, region string) (*regionClient, error) {
+		return &regionClient{region: region}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register keyed service: %v", err)
+	}
+
+	if _, err := container.Resolve((**regionClient)(nil)); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT resolving a keyed service without a key, got %v", err)
+	}
+	if _, err := ResolveKeyed[*TestRepository](container, "eu-west-1"); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	err = container.Register((*regionClient)(nil), func() *regionClient { return nil }, Keyed)
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT registering Keyed without RegisterKeyed, got %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Expected keyed registrations to validate, got %v", err)
+	}
+}
+
+func TestResolveKeyedNilInstance(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterKeyed(container, func(c *Container, key string) (TestInterface, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register keyed service: %v", err)
+	}
+
+	instance, err := ResolveKeyed[TestInterface](container, "eu-west-1")
+	if err != nil || instance != nil {
+		t.Errorf("Expected a nil instance without panicking, got %v, %v", instance, err)
+	}
+}
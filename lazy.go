@@ -0,0 +1,85 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Lazy defers resolving T until Get is first called, then keeps the result.
+// Any factory can take a *Lazy[T] parameter, or resolve one, as long as T is
+// registered; T itself is not built until it is needed:
+//
+//	func NewReportService(exporter *inject.Lazy[*PDFExporter]) *ReportService
+type Lazy[T any] struct {
+	once    sync.Once
+	resolve func() (interface{}, error)
+	value   T
+	err     error
+}
+
+// Get resolves T on the first call and returns the same outcome every time
+// after.
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		instance, err := l.resolve()
+		if err != nil {
+			l.err = err
+			return
+		}
+		if instance != nil {
+			l.value = instance.(T)
+		}
+	})
+	return l.value, l.err
+}
+
+func (l *Lazy[T]) MustGet() T {
+	value, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func (l *Lazy[T]) lazyTarget() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (l *Lazy[T]) setResolve(resolve func() (interface{}, error)) {
+	l.resolve = resolve
+}
+
+type lazyValue interface {
+	lazyTarget() reflect.Type
+	setResolve(func() (interface{}, error))
+}
+
+var lazyValueType = reflect.TypeFor[lazyValue]()
+
+// lazyKey maps a *Lazy[T] type to T.
+func lazyKey(serviceType reflect.Type) (reflect.Type, bool) {
+	if serviceType.Kind() != reflect.Ptr || !serviceType.Implements(lazyValueType) {
+		return nil, false
+	}
+	return reflect.Zero(serviceType).Interface().(lazyValue).lazyTarget(), true
+}
+
+// resolveLazy returns a new *Lazy[T] that resolves target through a handle of
+// its own, since it outlives the resolution that injected it. The handle
+// keeps the injecting frame, so Get called from within that factory is a
+// nested resolution and does not take the lock again.
+func (c *Container) resolveLazy(serviceType, target reflect.Type) (interface{}, error) {
+	if !c.has(target) {
+		return nil, c.notRegisteredError(target)
+	}
+	handle := &Container{containerCore: c.containerCore, frame: c.frame, scope: c.scope, caller: c.caller}
+	lazy := reflect.New(serviceType.Elem()).Interface()
+	lazy.(lazyValue).setResolve(func() (interface{}, error) {
+		if err := handle.checkPolicy(target); err != nil {
+			return nil, err
+		}
+		defer handle.readLock()()
+		return handle.resolveType(target)
+	})
+	return lazy, nil
+}
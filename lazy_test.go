@@ -0,0 +1,103 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type lazyConsumer struct {
+	repo *Lazy[*TestRepository]
+}
+
+func TestLazy(t *testing.T) {
+	container := NewContainer(WithDependencyChecks())
+
+	builds := 0
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		builds++
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = container.RegisterSingleton((**lazyConsumer)(nil), func(repo *Lazy[*TestRepository]) *lazyConsumer {
+		return &lazyConsumer{repo: repo}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register consumer: %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Lazy dependencies on registered services should validate: %v", err)
+	}
+
+	consumer := MustResolve[*lazyConsumer](container)
+	if builds != 0 {
+		t.Fatal("Lazy dependency should not be built before Get")
+	}
+
+	first, err := consumer.repo.Get()
+	if err != nil {
+		t.Fatalf("Failed to get lazy dependency: %v", err)
+	}
+	if consumer.repo.MustGet() != first || builds != 1 {
+		t.Errorf("Expected one build shared by every Get, got %d builds", builds)
+	}
+	if first != MustResolve[*TestRepository](container) {
+		t.Error("Lazy should resolve the registered singleton")
+	}
+
+	if !container.Has((**Lazy[*TestRepository])(nil)) {
+		t.Error("Has should report lazy wrappers of registered services")
+	}
+	_, err = container.Resolve((**Lazy[*TestService])(nil))
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected %s for a lazy unregistered service, got %v", ErrCodeNotRegistered, err)
+	}
+}
+
+func TestLazyPolicy(t *testing.T) {
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		if request.ServiceType == reflect.TypeFor[*TestRepository]() {
+			return errors.New("repositories must be injected")
+		}
+		return nil
+	}))
+
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	lazy := MustResolve[*Lazy[*TestRepository]](container)
+	if _, err := lazy.Get(); ErrorCodeOf(err) != ErrCodeAccessDenied {
+		t.Errorf("Expected ACCESS_DENIED from Get, got %v", err)
+	}
+}
+
+func TestLazyGetInsideFactory(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = container.RegisterSingleton((**lazyConsumer)(nil), func(repo *Lazy[*TestRepository]) (*lazyConsumer, error) {
+		if _, err := repo.Get(); err != nil {
+			return nil, err
+		}
+		return &lazyConsumer{repo: repo}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register consumer: %v", err)
+	}
+
+	consumer := MustResolve[*lazyConsumer](container)
+	if repo := consumer.repo.MustGet(); repo != MustResolve[*TestRepository](container) {
+		t.Error("Get inside and after the factory should return the same singleton")
+	}
+}
@@ -0,0 +1,78 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type RequestHandler[Req, Res any] interface {
+	Handle(ctx context.Context, request Req) (Res, error)
+}
+
+type RequestHandlerFunc[Req, Res any] func(ctx context.Context, request Req) (Res, error)
+
+func (f RequestHandlerFunc[Req, Res]) Handle(ctx context.Context, request Req) (Res, error) {
+	return f(ctx, request)
+}
+
+type NextFunc[Req, Res any] func(ctx context.Context, request Req) (Res, error)
+
+type PipelineBehavior[Req, Res any] interface {
+	Handle(ctx context.Context, request Req, next NextFunc[Req, Res]) (Res, error)
+}
+
+type PipelineBehaviorFunc[Req, Res any] func(ctx context.Context, request Req, next NextFunc[Req, Res]) (Res, error)
+
+func (f PipelineBehaviorFunc[Req, Res]) Handle(ctx context.Context, request Req, next NextFunc[Req, Res]) (Res, error) {
+	return f(ctx, request, next)
+}
+
+func RegisterRequestHandler[Req, Res any](container Registrar, factory func(*Container) RequestHandler[Req, Res], lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerTyped(container, (*RequestHandler[Req, Res])(nil), factory, lifecycle, opts)
+}
+
+func AddPipelineBehavior[Req, Res any](container *Container, factory func(*Container) PipelineBehavior[Req, Res]) error {
+	if factory == nil {
+		return newError(ErrCodeInvalidFactory, nil, "behavior factory must not be nil")
+	}
+
+	container.mu.Lock()
+	defer container.mu.Unlock()
+
+	handlerType := reflect.TypeOf((*RequestHandler[Req, Res])(nil)).Elem()
+	container.pipelines[handlerType] = append(container.pipelines[handlerType], factory)
+	return nil
+}
+
+// Send resolves the handler registered for Req and invokes it through the
+// pipeline behaviors added for the same request/response pair. Behaviors
+// wrap the handler in the order they were added, so the first one added is
+// the outermost.
+func Send[Req, Res any](container *Container, ctx context.Context, request Req) (Res, error) {
+	var zero Res
+
+	service, err := container.Resolve((*RequestHandler[Req, Res])(nil))
+	if err != nil {
+		return zero, fmt.Errorf("no handler for request %T: %w", request, err)
+	}
+	handler := service.(RequestHandler[Req, Res])
+
+	container.mu.RLock()
+	factories := append([]interface{}(nil), container.pipelines[reflect.TypeOf((*RequestHandler[Req, Res])(nil)).Elem()]...)
+	container.mu.RUnlock()
+
+	next := NextFunc[Req, Res](handler.Handle)
+	for i := len(factories) - 1; i >= 0; i-- {
+		behavior := factories[i].(func(*Container) PipelineBehavior[Req, Res])(container)
+		if behavior == nil {
+			return zero, newError(ErrCodeFactoryError, nil, "behavior factory for request %T returned nil", request)
+		}
+		inner := next
+		next = func(ctx context.Context, request Req) (Res, error) {
+			return behavior.Handle(ctx, request, inner)
+		}
+	}
+
+	return next(ctx, request)
+}
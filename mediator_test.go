@@ -0,0 +1,102 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type TestCreateUser struct {
+	Name string
+}
+
+type TestCreatedUser struct {
+	ID   int
+	Name string
+}
+
+func TestSendInvokesRegisteredHandler(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "handled-"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+
+	err = RegisterRequestHandler[TestCreateUser, *TestCreatedUser](container, func(c *Container) RequestHandler[TestCreateUser, *TestCreatedUser] {
+		dep := MustResolve[TestInterface](c)
+		return RequestHandlerFunc[TestCreateUser, *TestCreatedUser](func(ctx context.Context, request TestCreateUser) (*TestCreatedUser, error) {
+			return &TestCreatedUser{ID: 1, Name: dep.GetValue() + request.Name}, nil
+		})
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	user, err := Send[TestCreateUser, *TestCreatedUser](container, context.Background(), TestCreateUser{Name: "erin"})
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if user.Name != "handled-erin" {
+		t.Errorf("Expected 'handled-erin', got '%s'", user.Name)
+	}
+}
+
+func TestSendRunsPipelineBehaviorsInOrder(t *testing.T) {
+	container := NewContainer()
+
+	var trace []string
+	err := RegisterRequestHandler[TestCreateUser, *TestCreatedUser](container, func(c *Container) RequestHandler[TestCreateUser, *TestCreatedUser] {
+		return RequestHandlerFunc[TestCreateUser, *TestCreatedUser](func(ctx context.Context, request TestCreateUser) (*TestCreatedUser, error) {
+			trace = append(trace, "handler")
+			return &TestCreatedUser{Name: request.Name}, nil
+		})
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	for _, name := range []string{"logging", "validation"} {
+		name := name
+		err := AddPipelineBehavior[TestCreateUser, *TestCreatedUser](container, func(c *Container) PipelineBehavior[TestCreateUser, *TestCreatedUser] {
+			return PipelineBehaviorFunc[TestCreateUser, *TestCreatedUser](func(ctx context.Context, request TestCreateUser, next NextFunc[TestCreateUser, *TestCreatedUser]) (*TestCreatedUser, error) {
+				trace = append(trace, name)
+				if name == "validation" && request.Name == "" {
+					return nil, errors.New("name required")
+				}
+				return next(ctx, request)
+			})
+		})
+		if err != nil {
+			t.Fatalf("Failed to add behavior: %v", err)
+		}
+	}
+
+	if _, err := Send[TestCreateUser, *TestCreatedUser](container, context.Background(), TestCreateUser{Name: "frank"}); err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	if strings.Join(trace, ",") != "logging,validation,handler" {
+		t.Errorf("Unexpected pipeline order: %v", trace)
+	}
+
+	trace = nil
+	_, err = Send[TestCreateUser, *TestCreatedUser](container, context.Background(), TestCreateUser{})
+	if err == nil || err.Error() != "name required" {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+	if strings.Join(trace, ",") != "logging,validation" {
+		t.Errorf("Behavior should be able to short-circuit the handler, got %v", trace)
+	}
+}
+
+func TestSendWithoutHandler(t *testing.T) {
+	container := NewContainer()
+
+	_, err := Send[TestCreateUser, *TestCreatedUser](container, context.Background(), TestCreateUser{Name: "gina"})
+	if err == nil {
+		t.Error("Expected error when no handler is registered")
+	}
+}
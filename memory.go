@@ -0,0 +1,40 @@
+package inject
+
+import "runtime/metrics"
+
+// WithMemoryAccounting records the heap allocated while each singleton is
+// constructed and reports it as ServiceStats.ApproxBytes. The figure is
+// approximate: it counts allocations made by other goroutines meanwhile and
+// memory the factory allocated but did not keep, and it excludes singletons
+// built as dependencies, which are accounted to themselves.
+func WithMemoryAccounting() ContainerOption {
+	return func(c *Container) {
+		c.memoryAccounting = true
+	}
+}
+
+func heapAllocated() uint64 {
+	sample := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// accountMemory attributes the allocations of a finished singleton
+// construction, minus those of singletons nested in it, to the descriptor.
+func (f *resolveFrame) accountMemory(descriptor *ServiceDescriptor, allocated uint64) {
+	own := uint64(0)
+	if allocated > f.nestedAlloc {
+		own = allocated - f.nestedAlloc
+	}
+	descriptor.stats.approxBytes.Store(own)
+
+	for parent := f.parent; parent != nil; parent = parent.parent {
+		if parent.singleton {
+			parent.nestedAlloc += allocated
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package inject
+
+import (
+	"testing"
+)
+
+type TestCache struct {
+	data []byte
+}
+
+type TestIndex struct {
+	cache *TestCache
+	data  []byte
+}
+
+func TestMemoryAccounting(t *testing.T) {
+	container := NewContainer(WithMemoryAccounting())
+
+	err := RegisterSingletonType[*TestCache](container, func(c *Container) *TestCache {
+		return &TestCache{data: make([]byte, 16<<20)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterSingletonType[*TestIndex](container, func(c *Container) *TestIndex {
+		return &TestIndex{cache: MustResolve[*TestCache](c), data: make([]byte, 4<<20)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	MustResolve[*TestIndex](container)
+
+	bytes := make(map[string]uint64)
+	for _, service := range container.Stats().Services {
+		bytes[service.ServiceType.String()] = service.ApproxBytes
+	}
+	if cache := bytes["*inject.TestCache"]; cache < 16<<20 {
+		t.Errorf("Expected at least 16 MiB for the cache, got %d", cache)
+	}
+	if index := bytes["*inject.TestIndex"]; index < 4<<20 || index >= 16<<20 {
+		t.Errorf("Index should not be charged for the nested cache singleton, got %d", index)
+	}
+}
+
+func TestMemoryAccountingDisabled(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestCache](container, func(c *Container) *TestCache {
+		return &TestCache{data: make([]byte, 1<<20)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	MustResolve[*TestCache](container)
+
+	if container.Stats().Services[0].ApproxBytes != 0 {
+		t.Error("Memory should only be accounted when enabled")
+	}
+}
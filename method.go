@@ -0,0 +1,80 @@
+package inject
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// isMethodExpression reports whether fn is a method expression such as
+// (*Registry).NewClient, whose first parameter is the receiver. Bound method
+// values (registry.NewClient) are ordinary functions and need no special
+// handling.
+func isMethodExpression(fn interface{}) bool {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() || fnValue.Type().NumIn() == 0 {
+		return false
+	}
+	f := runtime.FuncForPC(fnValue.Pointer())
+	if f == nil {
+		return false
+	}
+
+	receiver := fnValue.Type().In(0)
+	named := receiver
+	if named.Kind() == reflect.Ptr {
+		named = named.Elem()
+	}
+	if named.Name() == "" {
+		return false
+	}
+
+	prefix := named.PkgPath() + "." + named.Name() + "."
+	if receiver.Kind() == reflect.Ptr {
+		prefix = named.PkgPath() + ".(*" + named.Name() + ")."
+	}
+	name := f.Name()
+	if len(name) <= len(prefix) || name[:len(prefix)] != prefix {
+		return false
+	}
+	_, ok := receiver.MethodByName(name[len(prefix):])
+	return ok
+}
+
+// receiverKey returns the registration that supplies a method expression's
+// receiver. Register((*Registry)(nil), ...) keys the service on Registry
+// while (*Registry).NewClient takes a *Registry, so the pointer and value
+// forms of the receiver are both accepted. Callers must hold c.mu.
+func (c *Container) receiverKey(receiver reflect.Type) reflect.Type {
+	if _, exists := c.services[serviceKey{serviceType: receiver}]; exists {
+		return receiver
+	}
+	alternate := reflect.PointerTo(receiver)
+	if receiver.Kind() == reflect.Ptr {
+		alternate = receiver.Elem()
+	}
+	if _, exists := c.services[serviceKey{serviceType: alternate}]; exists {
+		return alternate
+	}
+	return receiver
+}
+
+func (c *Container) resolveReceiver(receiver reflect.Type) (reflect.Value, error) {
+	instance, err := c.resolveType(c.receiverKey(receiver))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	value := reflect.ValueOf(instance)
+	switch {
+	case value.Type() == receiver:
+		return value, nil
+	case value.Kind() == reflect.Ptr && value.Type().Elem() == receiver:
+		return value.Elem(), nil
+	case receiver.Kind() == reflect.Ptr && value.Type() == receiver.Elem():
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return ptr, nil
+	default:
+		return reflect.Value{}, newError(ErrCodeTypeMismatch, receiver, "receiver of type %s cannot be built from %s", receiver.String(), value.Type().String())
+	}
+}
@@ -0,0 +1,94 @@
+package inject
+
+import (
+	"testing"
+)
+
+type testRegistry struct {
+	prefix string
+}
+
+func (r *testRegistry) NewClient() *testClient {
+	return &testClient{dsn: r.prefix + "/client"}
+}
+
+func (r testRegistry) NewConfig(env string) testConfig {
+	return testConfig{DSN: r.prefix + "/" + env}
+}
+
+func TestMethodExpressionFactory(t *testing.T) {
+	container := NewContainer(WithDependencyChecks())
+
+	err := container.RegisterSingleton((*testRegistry)(nil), func() *testRegistry {
+		return &testRegistry{prefix: "registry"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register registry: %v", err)
+	}
+	err = RegisterValue(container, "prod")
+	if err != nil {
+		t.Fatalf("Failed to register env: %v", err)
+	}
+
+	if err := container.RegisterTransient((*testClient)(nil), (*testRegistry).NewClient); err != nil {
+		t.Fatalf("Failed to register method expression: %v", err)
+	}
+	if err := container.RegisterTransient((*testConfig)(nil), testRegistry.NewConfig); err != nil {
+		t.Fatalf("Failed to register value receiver method expression: %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Receiver should satisfy validation: %v", err)
+	}
+
+	client, err := container.Resolve((*testClient)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve client: %v", err)
+	}
+	if client.(*testClient).dsn != "registry/client" {
+		t.Error("Receiver should be resolved from the container")
+	}
+
+	config, err := container.Resolve((*testConfig)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve config: %v", err)
+	}
+	if config.(testConfig).DSN != "registry/prod" {
+		t.Errorf("Unexpected config %v", config)
+	}
+}
+
+func TestMethodValueFactory(t *testing.T) {
+	container := NewContainer()
+	registry := &testRegistry{prefix: "bound"}
+
+	if err := container.RegisterSingleton((*testClient)(nil), registry.NewClient); err != nil {
+		t.Fatalf("Failed to register method value: %v", err)
+	}
+	client, err := container.Resolve((*testClient)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve client: %v", err)
+	}
+	if client.(*testClient).dsn != "bound/client" {
+		t.Error("Method value should be called on its bound receiver")
+	}
+}
+
+func TestIsMethodExpression(t *testing.T) {
+	registry := &testRegistry{}
+	tests := []struct {
+		name     string
+		fn       interface{}
+		expected bool
+	}{
+		{"pointer receiver", (*testRegistry).NewClient, true},
+		{"value receiver", testRegistry.NewConfig, true},
+		{"method value", registry.NewClient, false},
+		{"closure", func(r *testRegistry) *testClient { return nil }, false},
+		{"function", NewContainer, false},
+	}
+	for _, test := range tests {
+		if got := isMethodExpression(test.fn); got != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
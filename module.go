@@ -0,0 +1,57 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Module groups the registrations of one part of an application, so wiring
+// can live next to the code it wires and be composed with Apply.
+type Module struct {
+	Name     string
+	register func(*Container) error
+}
+
+func NewModule(name string, register func(c *Container) error) Module {
+	return Module{Name: name, register: register}
+}
+
+// Apply runs the registrations of each module. Every module is applied even
+// if an earlier one fails, and the failures are returned joined, each
+// prefixed with its module's name. A module may apply others, but applying
+// the same module name twice to a container is an error.
+func (c *Container) Apply(modules ...Module) error {
+	var errs []error
+	for _, module := range modules {
+		if err := c.apply(module); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Container) apply(module Module) error {
+	if module.register == nil {
+		return newError(ErrCodeInvalidArgument, nil, "module %q has no registrations", module.Name)
+	}
+
+	c.modulesMu.Lock()
+	if c.modules[module.Name] {
+		c.modulesMu.Unlock()
+		return newError(ErrCodeInvalidArgument, nil, "module %q is already applied", module.Name)
+	}
+	if c.modules == nil {
+		c.modules = make(map[string]bool)
+	}
+	c.modules[module.Name] = true
+	c.modulesMu.Unlock()
+
+	if err := module.register(c); err != nil {
+		// Let a fixed module be applied again
+		c.modulesMu.Lock()
+		delete(c.modules, module.Name)
+		c.modulesMu.Unlock()
+		return fmt.Errorf("module %q: %w", module.Name, err)
+	}
+	return nil
+}
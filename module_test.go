@@ -0,0 +1,44 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestApplyModules(t *testing.T) {
+	container := NewContainer()
+
+	storage := NewModule("storage", func(c *Container) error {
+		return c.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+			return &TestRepository{}
+		})
+	})
+	services := NewModule("services", func(c *Container) error {
+		if err := c.Apply(storage); err != nil {
+			return err
+		}
+		return c.RegisterTransient((**TestService)(nil), func(repo *TestRepository) *TestService {
+			return &TestService{}
+		})
+	})
+	broken := NewModule("broken", func(c *Container) error {
+		return errors.New("missing configuration")
+	})
+
+	err := container.Apply(services, broken)
+	if err == nil || !strings.Contains(err.Error(), `module "broken": missing configuration`) {
+		t.Errorf("Expected the failing module to be named, got %v", err)
+	}
+	if _, err := container.Resolve((**TestService)(nil)); err != nil {
+		t.Errorf("Modules before and after a failure should still be applied: %v", err)
+	}
+
+	err = container.Apply(storage)
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument || !strings.Contains(err.Error(), `"storage"`) {
+		t.Errorf("Expected a duplicate module error, got %v", err)
+	}
+	if err := container.Apply(NewModule("broken", func(c *Container) error { return nil })); err != nil {
+		t.Errorf("A module that failed should be applicable again: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Append adds the registration next to any existing one for the same type
+// and name instead of replacing it. Resolve keeps returning the first
+// registration, and ResolveAll returns all of them:
+//
+//	c.RegisterSingleton((*HealthCheck)(nil), NewDBCheck, inject.Append())
+//	c.RegisterSingleton((*HealthCheck)(nil), NewCacheCheck, inject.Append())
+//	checks, err := inject.ResolveAll[HealthCheck](c)
+func Append() RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.appended = true
+	}
+}
+
+// allResolver is implemented by resolvers that can resolve every
+// registration of a type.
+type allResolver interface {
+	resolveAll(serviceType reflect.Type) ([]interface{}, error)
+}
+
+// ResolveAll resolves every registration of T, named ones included, in the
+// order they were registered. Members of groups are left out, see
+// ResolveGroup. Every failure is reported in the joined error.
+func ResolveAll[T any](container Resolver) ([]T, error) {
+	serviceType := reflect.TypeFor[T]()
+	r, ok := container.(allResolver)
+	if !ok {
+		return nil, newError(ErrCodeInvalidArgument, serviceType, "resolver %T does not support ResolveAll", container)
+	}
+	instances, err := r.resolveAll(serviceType)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]T, len(instances))
+	for i, instance := range instances {
+		results[i], _ = instance.(T)
+	}
+	return results, nil
+}
+
+func (c *Container) resolveAll(serviceType reflect.Type) ([]interface{}, error) {
+	return c.resolveEach(serviceType, ungrouped)
+}
+
+// resolveEach resolves the registrations of serviceType whose keys match,
+// in the order they were registered.
+func (c *Container) resolveEach(serviceType reflect.Type, match func(serviceKey) bool) ([]interface{}, error) {
+	if err := c.checkPolicy(serviceType); err != nil {
+		return nil, err
+	}
+	defer c.readLock()()
+	return c.resolveMatching(serviceType, match)
+}
+
+// resolveMatching is resolveEach for callers holding c.mu.
+func (c *Container) resolveMatching(serviceType reflect.Type, match func(serviceKey) bool) ([]interface{}, error) {
+	descriptors := c.matching(serviceType, match)
+	instances := make([]interface{}, 0, len(descriptors))
+	var errs []error
+	for _, descriptor := range descriptors {
+		instance, err := c.resolveDescriptor(descriptor)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve %s: %w", descriptor.displayName(), err))
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return instances, nil
+}
+
+// matching returns the registrations of serviceType whose keys match, in the
+// order they were registered. Callers must hold c.mu.
+func (c *Container) matching(serviceType reflect.Type, match func(serviceKey) bool) []*ServiceDescriptor {
+	var descriptors []*ServiceDescriptor
+	for key, descriptor := range c.services {
+		if key.serviceType == serviceType && match(key) {
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].registered < descriptors[j].registered
+	})
+	return descriptors
+}
+
+func (s *Scope) resolveAll(serviceType reflect.Type) ([]interface{}, error) {
+	return s.container.resolveAll(serviceType)
+}
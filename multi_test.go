@@ -0,0 +1,95 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolveAll(t *testing.T) {
+	container := NewContainer()
+
+	for _, name := range []string{"db", "cache", "queue"} {
+		err := container.RegisterSingleton((*TestGateway)(nil), func() TestGateway {
+			return &testGateway{name: name}
+		}, Append())
+		if err != nil {
+			t.Fatalf("Failed to register %s: %v", name, err)
+		}
+	}
+	err := RegisterNamed[TestGateway](container, "named", func(*Container) TestGateway {
+		return &testGateway{name: "named"}
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register named service: %v", err)
+	}
+
+	all, err := ResolveAll[TestGateway](container)
+	if err != nil {
+		t.Fatalf("Failed to resolve all: %v", err)
+	}
+	var names []string
+	for _, gateway := range all {
+		names = append(names, gateway.Name())
+	}
+	if strings.Join(names, ",") != "db,cache,queue,named" {
+		t.Errorf("Expected every registration in registration order, got %v", names)
+	}
+
+	if MustResolve[TestGateway](container).Name() != "db" {
+		t.Error("Expected Resolve to return the first registration")
+	}
+
+	again, _ := ResolveAll[TestGateway](container)
+	if again[0] != all[0] {
+		t.Error("Expected appended singletons to keep their instances")
+	}
+
+	none, err := ResolveAll[TestInterface](container)
+	if err != nil || len(none) != 0 {
+		t.Errorf("Expected no services and no error, got %v, %v", none, err)
+	}
+}
+
+func TestRegisterWithoutAppendReplaces(t *testing.T) {
+	container := NewContainer()
+
+	for _, name := range []string{"first", "second"} {
+		err := container.RegisterSingleton((*TestGateway)(nil), func() TestGateway {
+			return &testGateway{name: name}
+		})
+		if err != nil {
+			t.Fatalf("Failed to register %s: %v", name, err)
+		}
+	}
+
+	all, err := ResolveAll[TestGateway](container)
+	if err != nil {
+		t.Fatalf("Failed to resolve all: %v", err)
+	}
+	if len(all) != 1 || all[0].Name() != "second" {
+		t.Errorf("Expected the second registration to replace the first, got %v", all)
+	}
+}
+
+func TestResolveAllReportsFailures(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestGateway)(nil), func() (TestGateway, error) {
+		return nil, errors.New("first failed")
+	}, Append())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestGateway)(nil), func() (TestGateway, error) {
+		return nil, errors.New("second failed")
+	}, Append())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = ResolveAll[TestGateway](container)
+	if err == nil || !strings.Contains(err.Error(), "first failed") || !strings.Contains(err.Error(), "second failed") {
+		t.Errorf("Expected both failures to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithName registers the service under name, next to the unnamed
+// registration of its type and any others with different names, so several
+// implementations of one interface can coexist. Named services are resolved
+// with ResolveNamed.
+func WithName(name string) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.Name = name
+	}
+}
+
+// displayName is the type of the registration, followed by its name if it
+// has one, for listings.
+func (d *ServiceDescriptor) displayName() string {
+	name := d.ServiceType.String()
+	if d.Name != "" {
+		name = fmt.Sprintf("%s %q", name, d.Name)
+	}
+	if d.Group != "" {
+		name = fmt.Sprintf("%s in group %q", name, d.Group)
+	}
+	return name
+}
+
+func (d *ServiceDescriptor) key() serviceKey {
+	return serviceKey{serviceType: d.ServiceType, name: d.Name, group: d.Group, seq: d.seq}
+}
+
+// RegisterNamed registers T under name:
+//
+//	inject.RegisterNamed[PaymentGateway](c, "stripe", NewStripeGateway, inject.Singleton)
+//	gateway, err := inject.ResolveNamed[PaymentGateway](c, "stripe")
+func RegisterNamed[T any](container Registrar, name string, factory func(*Container) T, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return RegisterType[T](container, factory, lifecycle, append(opts[:len(opts):len(opts)], WithName(name))...)
+}
+
+// namedResolver is implemented by resolvers that can look up named
+// registrations.
+type namedResolver interface {
+	resolveNamed(serviceType reflect.Type, name string) (interface{}, error)
+}
+
+// ResolveNamed resolves the registration of T with the given name.
+func ResolveNamed[T any](container Resolver, name string) (T, error) {
+	var zero T
+	serviceType := reflect.TypeFor[T]()
+	r, ok := container.(namedResolver)
+	if !ok {
+		return zero, newError(ErrCodeInvalidArgument, serviceType, "resolver %T does not support named services", container)
+	}
+	instance, err := r.resolveNamed(serviceType, name)
+	if err != nil {
+		return zero, err
+	}
+	value, ok := instance.(T)
+	if !ok && instance != nil {
+		return zero, newError(ErrCodeTypeMismatch, serviceType, "service %q of type %T is not a %s", name, instance, serviceType.String())
+	}
+	return value, nil
+}
+
+func MustResolveNamed[T any](container Resolver, name string) T {
+	result, err := ResolveNamed[T](container, name)
+	if err != nil {
+		var zero T
+		panic(fmt.Sprintf("failed to resolve service of type %T named %q: %v", zero, name, err))
+	}
+	return result
+}
+
+func (c *Container) resolveNamed(serviceType reflect.Type, name string) (interface{}, error) {
+	if err := c.checkPolicy(serviceType); err != nil {
+		return nil, err
+	}
+	defer c.readLock()()
+
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType, name: name}]
+	if !exists {
+		return nil, newError(ErrCodeNotRegistered, serviceType, "service of type %s named %q not registered", serviceType.String(), name)
+	}
+	return c.resolveDescriptor(descriptor)
+}
+
+func (s *Scope) resolveNamed(serviceType reflect.Type, name string) (interface{}, error) {
+	return s.container.resolveNamed(serviceType, name)
+}
@@ -0,0 +1,104 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+type TestGateway interface {
+	Name() string
+}
+
+type testGateway struct {
+	name string
+}
+
+func (g *testGateway) Name() string {
+	return g.name
+}
+
+func TestNamedRegistrations(t *testing.T) {
+	container := NewContainer()
+
+	for _, name := range []string{"stripe", "paypal"} {
+		err := RegisterNamed[TestGateway](container, name, func(*Container) TestGateway {
+			return &testGateway{name: name}
+		}, Singleton)
+		if err != nil {
+			t.Fatalf("Failed to register %s: %v", name, err)
+		}
+	}
+	err := container.RegisterSingleton((*TestGateway)(nil), func() TestGateway {
+		return &testGateway{name: "default"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register default gateway: %v", err)
+	}
+
+	stripe, err := ResolveNamed[TestGateway](container, "stripe")
+	if err != nil {
+		t.Fatalf("Failed to resolve named service: %v", err)
+	}
+	if stripe.Name() != "stripe" || MustResolveNamed[TestGateway](container, "stripe") != stripe {
+		t.Error("Expected the named singleton")
+	}
+	if MustResolveNamed[TestGateway](container, "paypal").Name() != "paypal" {
+		t.Error("Expected each name to resolve its own registration")
+	}
+	if MustResolve[TestGateway](container).Name() != "default" {
+		t.Error("Expected the unnamed registration to be unaffected")
+	}
+
+	_, err = ResolveNamed[TestGateway](container, "adyen")
+	if ErrorCodeOf(err) != ErrCodeNotRegistered || !strings.Contains(err.Error(), `"adyen"`) {
+		t.Errorf("Expected NOT_REGISTERED naming the missing service, got %v", err)
+	}
+
+	if stats := container.Stats(); stats.Registrations != 3 || stats.Services[0].Name != "" || stats.Services[1].Name != "paypal" {
+		t.Errorf("Expected named registrations in stats, got %+v", stats.Services)
+	}
+}
+
+func TestNamedWithScopeAndAlias(t *testing.T) {
+	container := NewContainer()
+
+	err := container.Register((**testGateway)(nil), func() *testGateway {
+		return &testGateway{name: "scoped"}
+	}, Scoped, WithName("primary"), As[TestGateway]())
+	if err != nil {
+		t.Fatalf("Failed to register named service: %v", err)
+	}
+
+	scope := container.NewScope()
+	defer scope.Close()
+	gateway, err := ResolveNamed[*testGateway](scope, "primary")
+	if err != nil {
+		t.Fatalf("Failed to resolve named service from scope: %v", err)
+	}
+	alias, err := ResolveNamed[TestGateway](scope, "primary")
+	if err != nil {
+		t.Fatalf("Failed to resolve named alias: %v", err)
+	}
+	if alias != gateway {
+		t.Error("Expected the named alias to resolve the same scoped instance")
+	}
+	if container.Has((*TestGateway)(nil)) {
+		t.Error("Expected a named alias not to register the unnamed type")
+	}
+}
+
+func TestResolveNamedNilInstance(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterNamed[TestInterface](container, "disabled", func(c *Container) TestInterface {
+		return nil
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register named service: %v", err)
+	}
+
+	instance, err := ResolveNamed[TestInterface](container, "disabled")
+	if err != nil || instance != nil {
+		t.Errorf("Expected a nil instance without panicking, got %v, %v", instance, err)
+	}
+}
@@ -0,0 +1,47 @@
+package inject
+
+import (
+	"reflect"
+	"time"
+)
+
+// ResolveEvent describes one resolution. Parent is the service whose
+// factory asked for this one, or nil for a top-level request. Cached is set
+// when an existing instance was returned instead of a new one being built.
+type ResolveEvent struct {
+	ServiceType reflect.Type
+	Name        string
+	Lifecycle   Lifecycle
+	Cached      bool
+	Parent      reflect.Type
+	Duration    time.Duration
+	Err         error
+}
+
+type ResolveHook func(event ResolveEvent)
+
+// SetResolveHook installs a hook called after every resolution, nested ones
+// included, for logging and metrics. The hook runs on the resolving
+// goroutine, so it should be quick. Pass nil to remove it.
+func (c *Container) SetResolveHook(hook ResolveHook) {
+	if hook == nil {
+		c.resolveHook.Store(nil)
+		return
+	}
+	c.resolveHook.Store(&hook)
+}
+
+func (c *Container) resolveEvent(descriptor *ServiceDescriptor, cached bool, start time.Time, err error) ResolveEvent {
+	event := ResolveEvent{
+		ServiceType: descriptor.ServiceType,
+		Name:        descriptor.Name,
+		Lifecycle:   descriptor.Lifecycle,
+		Cached:      cached,
+		Duration:    time.Since(start),
+		Err:         err,
+	}
+	if c.frame != nil && !c.frame.done.Load() {
+		event.Parent = c.frame.serviceType
+	}
+	return event
+}
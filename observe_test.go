@@ -0,0 +1,60 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestResolveHook(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+	err = RegisterTransientType[*TestService](container, func(c *Container) *TestService {
+		MustResolve[*TestRepository](c)
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	var mu sync.Mutex
+	var events []ResolveEvent
+	container.SetResolveHook(func(event ResolveEvent) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	})
+
+	MustResolve[*TestService](container)
+	MustResolve[*TestService](container)
+
+	repoType := reflect.TypeFor[*TestRepository]()
+	serviceType := reflect.TypeFor[*TestService]()
+	expected := []ResolveEvent{
+		{ServiceType: repoType, Lifecycle: Singleton, Cached: false, Parent: serviceType},
+		{ServiceType: serviceType, Lifecycle: Transient, Cached: false},
+		{ServiceType: repoType, Lifecycle: Singleton, Cached: true, Parent: serviceType},
+		{ServiceType: serviceType, Lifecycle: Transient, Cached: false},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %v", len(expected), events)
+	}
+	for i, want := range expected {
+		got := events[i]
+		if got.ServiceType != want.ServiceType || got.Lifecycle != want.Lifecycle || got.Cached != want.Cached || got.Parent != want.Parent {
+			t.Errorf("Event %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	container.SetResolveHook(nil)
+	MustResolve[*TestService](container)
+	if len(events) != len(expected) {
+		t.Error("Removed hook should not be called")
+	}
+}
@@ -0,0 +1,52 @@
+package inject
+
+import (
+	"reflect"
+)
+
+type RegistrationOption func(descriptor *ServiceDescriptor)
+
+func WithMetadata(key string, value interface{}) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		if descriptor.Metadata == nil {
+			descriptor.Metadata = make(map[string]interface{})
+		}
+		descriptor.Metadata[key] = value
+	}
+}
+
+func WithDescription(description string) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.Description = description
+	}
+}
+
+func WithTags(tags ...string) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.Tags = append(descriptor.Tags, tags...)
+	}
+}
+
+// WithFallback registers a degraded factory used when the primary factory
+// (or one of its dependencies) fails, e.g. an in-memory cache when Redis is
+// down. Services built by the fallback are reported as degraded in Stats.
+func WithFallback(factory interface{}) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.fallback = factory
+	}
+}
+
+func (c *Container) checkFallback(descriptor *ServiceDescriptor) error {
+	if c.noReflection {
+		return newError(ErrCodeReflectionDisabled, descriptor.ServiceType, "fallback factories are called through reflection and are disabled")
+	}
+
+	fallbackType := reflect.TypeOf(descriptor.fallback)
+	if fallbackType.Kind() != reflect.Func {
+		return newError(ErrCodeInvalidFactory, descriptor.ServiceType, "fallback factory must be a function")
+	}
+	if err := validateFactoryResults(fallbackType); err != nil {
+		return err
+	}
+	return checkReturnType(descriptor.ServiceType, fallbackType.Out(0))
+}
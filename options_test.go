@@ -0,0 +1,76 @@
+package inject
+
+import (
+	"testing"
+)
+
+func TestWithMetadata(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	}, WithMetadata("owner", "platform-team"), WithMetadata("tier", 1))
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	err = container.RegisterTransient((*TestService)(nil), func() *TestService {
+		return &TestService{}
+	}, WithMetadata("owner", "payments-team"), WithMetadata("classification", []string{"pii"}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	descriptor, ok := container.Descriptor((*TestInterface)(nil))
+	if !ok {
+		t.Fatal("Descriptor should be found for registered service")
+	}
+	if descriptor.Metadata["owner"] != "platform-team" || descriptor.Metadata["tier"] != 1 {
+		t.Errorf("Unexpected metadata: %v", descriptor.Metadata)
+	}
+
+	owned := container.Descriptors(ByMetadata("owner", "payments-team"))
+	if len(owned) != 1 || owned[0].ServiceType.String() != "inject.TestService" {
+		t.Error("ByMetadata should select services by metadata value")
+	}
+
+	if len(container.Descriptors(ByMetadata("classification", []string{"pii"}))) != 1 {
+		t.Error("ByMetadata should compare non-comparable values")
+	}
+
+	if _, ok := container.Descriptor((*TestRepository)(nil)); ok {
+		t.Error("Descriptor should not be found for unregistered service")
+	}
+}
+
+func TestWithMetadataOnRegisterFunc(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterFunc(func() *TestImplementation {
+		return &TestImplementation{}
+	}, Singleton, WithMetadata("slo", "gold"))
+	if err != nil {
+		t.Fatalf("Failed to register func: %v", err)
+	}
+
+	descriptor, ok := container.Descriptor((**TestImplementation)(nil))
+	if !ok || descriptor.Metadata["slo"] != "gold" {
+		t.Error("RegisterFunc should apply registration options")
+	}
+}
+
+func TestWithDescription(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{}
+	}, WithDescription("primary Postgres pool"))
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	descriptor, ok := container.Descriptor((**TestRepository)(nil))
+	if !ok || descriptor.Description != "primary Postgres pool" {
+		t.Error("WithDescription should set the descriptor description")
+	}
+}
@@ -0,0 +1,141 @@
+package inject
+
+import (
+	"reflect"
+)
+
+// In marks a parameter object. A factory parameter whose struct type embeds
+// In is not resolved itself; each of its exported fields is resolved and
+// the filled struct is passed instead, which keeps constructors with many
+// dependencies readable:
+//
+//	type ServerParams struct {
+//		inject.In
+//
+//		Log     Logger
+//		Primary *sql.DB `name:"primary"`
+//		Metrics Metrics `optional:"true"`
+//	}
+//
+//	func NewServer(p ServerParams) *Server
+//
+// A field tagged name resolves the registration made WithName. A []T field
+// tagged group receives the members of that group of T, see WithGroup. One
+// tagged optional is left at its zero value when its type is not
+// registered.
+type In struct{}
+
+var inType = reflect.TypeFor[In]()
+
+type paramField struct {
+	index       int
+	serviceType reflect.Type
+	name        string
+	group       string
+	optional    bool
+}
+
+// isParamStruct reports whether t is a struct embedding In.
+func isParamStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// paramFields lists the fields of the parameter object t that are resolved
+// from the container.
+func paramFields(t reflect.Type) []paramField {
+	var fields []paramField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type == inType {
+			continue
+		}
+		fields = append(fields, paramField{
+			index:       i,
+			serviceType: field.Type,
+			name:        field.Tag.Get("name"),
+			group:       field.Tag.Get("group"),
+			optional:    field.Tag.Get("optional") == "true",
+		})
+	}
+	return fields
+}
+
+// registered reports whether the field's dependency can be resolved.
+// Callers must hold c.mu.
+func (f paramField) registered(c *Container) bool {
+	if f.serviceType == reflect.TypeOf((*Container)(nil)) || f.serviceType == contextType || f.group != "" {
+		return true
+	}
+	if f.name != "" {
+		_, exists := c.services[serviceKey{serviceType: f.serviceType, name: f.name}]
+		return exists
+	}
+	return c.has(f.serviceType)
+}
+
+// resolveParams builds the parameter object paramType with each field
+// resolved from the container.
+func (c *Container) resolveParams(paramType reflect.Type) (reflect.Value, error) {
+	defer c.readLock()()
+
+	params := reflect.New(paramType).Elem()
+	for _, f := range paramFields(paramType) {
+		if f.optional && !f.registered(c) {
+			continue
+		}
+
+		var dep interface{}
+		var err error
+		switch {
+		case f.serviceType == reflect.TypeOf((*Container)(nil)):
+			dep = c
+		case f.serviceType == contextType:
+			dep = c.context()
+		case f.group != "":
+			dep, err = c.resolveGroupSlice(f.serviceType, f.group)
+		case f.name != "":
+			descriptor, exists := c.services[serviceKey{serviceType: f.serviceType, name: f.name}]
+			if !exists {
+				err = newError(ErrCodeNotRegistered, f.serviceType, "service of type %s named %q not registered", f.serviceType.String(), f.name)
+				break
+			}
+			dep, err = c.resolveDescriptor(descriptor)
+		default:
+			dep, err = c.resolveType(f.serviceType)
+		}
+		if err != nil {
+			return reflect.Value{}, dependencyError(f.serviceType, err)
+		}
+		if dep != nil {
+			params.Field(f.index).Set(reflect.ValueOf(dep))
+		}
+	}
+	return params, nil
+}
+
+// resolveGroupSlice resolves the members of the group of sliceType's element
+// type into a slice of sliceType. Callers must hold c.mu.
+func (c *Container) resolveGroupSlice(sliceType reflect.Type, group string) (interface{}, error) {
+	if sliceType.Kind() != reflect.Slice {
+		return nil, newError(ErrCodeInvalidArgument, sliceType, "group %q must be injected into a slice, got %s", group, sliceType.String())
+	}
+	instances, err := c.resolveMatching(sliceType.Elem(), func(key serviceKey) bool {
+		return key.group == group
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := reflect.MakeSlice(sliceType, 0, len(instances))
+	for _, instance := range instances {
+		members = reflect.Append(members, valueOrZero(sliceType.Elem(), instance))
+	}
+	return members.Interface(), nil
+}
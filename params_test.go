@@ -0,0 +1,81 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+type serviceParams struct {
+	In
+
+	Dependency TestInterface
+	Backup     TestInterface   `name:"backup"`
+	Repository *TestRepository `optional:"true"`
+	Container  *Container
+	ignored    TestInterface
+}
+
+func TestParamStruct(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "primary"})
+	if err != nil {
+		t.Fatalf("Failed to register dependency: %v", err)
+	}
+	err = RegisterValueAs[TestInterface](container, &TestImplementation{value: "backup"}, WithName("backup"))
+	if err != nil {
+		t.Fatalf("Failed to register named dependency: %v", err)
+	}
+
+	var got serviceParams
+	err = container.RegisterTransient((**TestService)(nil), func(p serviceParams) *TestService {
+		got = p
+		return &TestService{dependency: p.Dependency}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Optional fields should not fail validation: %v", err)
+	}
+
+	service := MustResolve[*TestService](container)
+	if service.GetDependency().GetValue() != "primary" {
+		t.Error("Param struct fields should be resolved from the container")
+	}
+	if got.Backup.GetValue() != "backup" {
+		t.Error("Fields tagged name should resolve the named registration")
+	}
+	if got.Repository != nil || got.ignored != nil {
+		t.Error("Optional and unexported fields should be left at their zero value")
+	}
+	if got.Container == nil {
+		t.Error("A *Container field should receive the container")
+	}
+
+	err = container.Invoke(func(p serviceParams) {
+		got = p
+	})
+	if err != nil || got.Dependency == nil {
+		t.Errorf("Invoke should fill param structs, got %v", err)
+	}
+}
+
+func TestParamStructMissingField(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((**TestService)(nil), func(p serviceParams) *TestService {
+		return &TestService{dependency: p.Dependency}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((**TestService)(nil))
+	if ErrorCodeOf(err) != ErrCodeNotRegistered || !strings.Contains(err.Error(), "inject.TestInterface") {
+		t.Errorf("Expected NOT_REGISTERED naming the field type, got %v", err)
+	}
+	if err := container.Validate(); err == nil || !strings.Contains(err.Error(), "inject.TestInterface") {
+		t.Errorf("Validate should report the missing field dependency, got %v", err)
+	}
+}
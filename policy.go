@@ -0,0 +1,92 @@
+package inject
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ResolutionRequest describes a resolution for a ResolutionPolicy. The caller
+// is the first stack frame outside this package, so for a lookup made inside
+// a factory it is the factory's package. ResolveAsync and PublishAsync
+// report the code that called them.
+type ResolutionRequest struct {
+	ServiceType   reflect.Type
+	CallerPackage string
+	Caller        string
+	Function      string
+}
+
+// ResolutionPolicy is consulted whenever a service is requested through
+// Resolve or the generic resolve helpers; returning an error denies the
+// request. Requesting a []T or map[string]T collection checks T as well.
+// Dependencies the container injects into factory parameters, collections
+// included, are not checked.
+type ResolutionPolicy func(request ResolutionRequest) error
+
+func WithResolutionPolicy(policy ResolutionPolicy) ContainerOption {
+	return func(c *Container) {
+		c.policy = policy
+	}
+}
+
+func (c *Container) checkPolicy(serviceType reflect.Type) error {
+	if c.policy == nil {
+		return nil
+	}
+
+	caller, function := externalCaller()
+	if function == unknownCaller && c.caller != nil {
+		caller, function = c.caller.caller, c.caller.function
+	}
+	for _, requested := range c.policyTypes(serviceType) {
+		request := ResolutionRequest{
+			ServiceType:   requested,
+			CallerPackage: functionPackage(function),
+			Caller:        caller,
+			Function:      function,
+		}
+		if err := c.policy(request); err != nil {
+			return newError(ErrCodeAccessDenied, requested, "resolution of %s from %s denied: %w", requested.String(), request.CallerPackage, err)
+		}
+	}
+	return nil
+}
+
+// policyTypes lists the types a request for serviceType is checked against:
+// the type itself, and the element type of a collection built from
+// registrations of it.
+func (c *Container) policyTypes(serviceType reflect.Type) []reflect.Type {
+	defer c.readLock()()
+	if _, registered := c.services[serviceKey{serviceType: serviceType}]; !registered {
+		if elem, ok := c.collectionKey(serviceType); ok {
+			return []reflect.Type{serviceType, elem}
+		}
+	}
+	return []reflect.Type{serviceType}
+}
+
+// policyCaller is the caller of an asynchronous resolution, captured before
+// it moves to a goroutine whose stack no longer leads back to it.
+type policyCaller struct {
+	caller   string
+	function string
+}
+
+// withCaller returns a handle whose policy checks report the code calling
+// the function that calls withCaller when the stack does not show it.
+func (c *Container) withCaller() *Container {
+	caller, function := externalCaller()
+	handle := *c
+	handle.caller = &policyCaller{caller: caller, function: function}
+	return &handle
+}
+
+// functionPackage extracts the import path from a qualified function name
+// such as "github.com/acme/app/handlers.(*Server).routes".
+func functionPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
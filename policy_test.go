@@ -0,0 +1,126 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestResolutionPolicy(t *testing.T) {
+	var requests []ResolutionRequest
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		requests = append(requests, request)
+		if request.ServiceType == serviceTypeOf((*TestInterface)(nil)) {
+			return errors.New("infrastructure types must be injected")
+		}
+		return nil
+	}))
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "db"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestInterface)(nil))
+	if ErrorCodeOf(err) != ErrCodeAccessDenied {
+		t.Errorf("Expected ACCESS_DENIED, got %v", err)
+	}
+	if _, ok := TryResolve[TestInterface](container); ok {
+		t.Error("Policy should apply to the generic helpers")
+	}
+
+	requests = nil
+	if _, err := container.Resolve((*TestService)(nil)); err != nil {
+		t.Fatalf("Injected dependencies should not be checked: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Expected one policy check, got %d", len(requests))
+	}
+	if requests[0].CallerPackage != packagePath || requests[0].Caller == "" {
+		t.Errorf("Request should carry caller information, got %+v", requests[0])
+	}
+}
+
+func TestFunctionPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/acme/app/handlers.(*Server).routes": "github.com/acme/app/handlers",
+		"github.com/acme/app.main.func1":                "github.com/acme/app",
+		"main.main":                                     "main",
+	}
+	for function, expected := range tests {
+		if pkg := functionPackage(function); pkg != expected {
+			t.Errorf("functionPackage(%q) = %q, expected %q", function, pkg, expected)
+		}
+	}
+}
+
+func TestResolutionPolicyAsyncCaller(t *testing.T) {
+	var mu sync.Mutex
+	var packages []string
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		packages = append(packages, request.CallerPackage)
+		return nil
+	}))
+
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "db"})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.Events().Subscribe((*TestUserCreated)(nil), func(dep TestInterface) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event interface{}) error {
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to subscribe handler: %v", err)
+	}
+
+	if _, err := ResolveAsync[TestInterface](container).Await(context.Background()); err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if err := <-container.Events().PublishAsync(context.Background(), TestUserCreated{}); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+	if len(packages) != 2 || packages[0] != packagePath || packages[1] != packagePath {
+		t.Errorf("Expected asynchronous requests to report their caller, got %v", packages)
+	}
+}
+
+func TestResolutionPolicyCollectionParameters(t *testing.T) {
+	container := NewContainer(WithResolutionPolicy(func(request ResolutionRequest) error {
+		if request.ServiceType == reflect.TypeFor[TestInterface]() {
+			return errors.New("infrastructure types must be injected")
+		}
+		return nil
+	}))
+
+	err := RegisterValueAs[TestInterface](container, &TestImplementation{value: "db"})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(deps []TestInterface) *TestService {
+		return &TestService{dependency: deps[0]}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, err := container.Resolve((*TestService)(nil)); err != nil {
+		t.Errorf("Injected collections should not be checked, like other parameters: %v", err)
+	}
+	if _, err := container.Resolve((*[]TestInterface)(nil)); ErrorCodeOf(err) != ErrCodeAccessDenied {
+		t.Errorf("Expected ACCESS_DENIED requesting the collection, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type PoolConfig struct {
+	// MaxSize bounds the number of instances checked out at once; zero
+	// means unbounded.
+	MaxSize int
+	// IdleTimeout discards instances that sat unused in the pool for longer;
+	// zero keeps idle instances forever.
+	IdleTimeout time.Duration
+	// HealthCheck is run on an idle instance before it is handed out.
+	// Instances that fail it are discarded and another one is used.
+	HealthCheck func(instance interface{}) error
+}
+
+type instancePool struct {
+	config PoolConfig
+	slots  chan struct{}
+	mu     sync.Mutex
+	idle   []idleInstance
+	inUse  int
+	closed bool
+	// failures are the errors disposing instances that left the pool,
+	// reported by Close
+	failures []error
+}
+
+type idleInstance struct {
+	value interface{}
+	since time.Time
+}
+
+func newInstancePool(config PoolConfig) *instancePool {
+	pool := &instancePool{config: config}
+	if config.MaxSize > 0 {
+		pool.slots = make(chan struct{}, config.MaxSize)
+	}
+	return pool
+}
+
+func WithPool(config PoolConfig) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.pool = newInstancePool(config)
+	}
+}
+
+// Lease is an instance checked out of a Pooled service. Call Release to
+// return it to the pool, or Discard if it turned out to be broken.
+type Lease[T any] struct {
+	Value    T
+	pool     *instancePool
+	instance interface{}
+	once     sync.Once
+}
+
+func (l *Lease[T]) Release() {
+	l.once.Do(func() {
+		l.pool.put(l.instance)
+	})
+}
+
+func (l *Lease[T]) Discard() {
+	l.once.Do(func() {
+		l.pool.discard(l.instance)
+	})
+}
+
+// Acquire checks out an instance of a Pooled service, waiting for one to be
+// released when the pool is at its maximum size. It fails once the container
+// is closed.
+func Acquire[T any](c *Container, ctx context.Context) (*Lease[T], error) {
+	serviceType := reflect.TypeFor[T]()
+	if err := c.checkPolicy(serviceType); err != nil {
+		return nil, err
+	}
+
+	unlock := c.readLock()
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType}]
+	if !exists {
+		defer unlock()
+		return nil, c.notRegisteredError(serviceType)
+	}
+	unlock()
+
+	if descriptor.Lifecycle != Pooled {
+		return nil, newError(ErrCodeInvalidArgument, serviceType, "service of type %s is not pooled", serviceType.String())
+	}
+	if c.closed.Load() {
+		return nil, errContainerClosed(serviceType)
+	}
+
+	instance, err := c.acquire(ctx, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := instance.(T)
+	if !ok {
+		descriptor.pool.put(instance)
+		return nil, newError(ErrCodeTypeMismatch, serviceType, "pooled instance of type %T is not a %s", instance, serviceType.String())
+	}
+	return &Lease[T]{Value: value, pool: descriptor.pool, instance: instance}, nil
+}
+
+func (c *Container) acquire(ctx context.Context, descriptor *ServiceDescriptor) (interface{}, error) {
+	pool := descriptor.pool
+	if pool.slots != nil {
+		select {
+		case pool.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for pooled %s: %w", descriptor.ServiceType.String(), ctx.Err())
+		}
+	}
+
+	for {
+		instance, ok := pool.takeIdle()
+		if !ok {
+			break
+		}
+		if pool.config.HealthCheck != nil {
+			if err := pool.config.HealthCheck(instance); err != nil {
+				pool.closeInstance(instance)
+				continue
+			}
+		}
+		pool.checkedOut()
+		return instance, nil
+	}
+
+	unlock := c.readLock()
+	instance, err := c.construct(descriptor)
+	unlock()
+	if err != nil {
+		pool.releaseSlot()
+		return nil, err
+	}
+	pool.checkedOut()
+	return instance, nil
+}
+
+// takeIdle pops the most recently returned instance, dropping any that have
+// been idle longer than the timeout.
+func (p *instancePool) takeIdle() (interface{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictIdle()
+	if len(p.idle) == 0 {
+		return nil, false
+	}
+	last := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return last.value, true
+}
+
+// evictIdle must be called with p.mu held.
+func (p *instancePool) evictIdle() {
+	if p.config.IdleTimeout <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-p.config.IdleTimeout)
+	kept := p.idle[:0]
+	for _, idle := range p.idle {
+		if idle.since.Before(cutoff) {
+			if err := dispose(idle.value); err != nil {
+				p.failures = append(p.failures, err)
+			}
+			continue
+		}
+		kept = append(kept, idle)
+	}
+	clear(p.idle[len(kept):])
+	p.idle = kept
+}
+
+func (p *instancePool) checkedOut() {
+	p.mu.Lock()
+	p.inUse++
+	p.mu.Unlock()
+}
+
+// put returns an instance to the pool, or disposes it once the pool has
+// been drained by Close.
+func (p *instancePool) put(instance interface{}) {
+	p.mu.Lock()
+	p.inUse--
+	closed := p.closed
+	if !closed {
+		p.idle = append(p.idle, idleInstance{value: instance, since: time.Now()})
+		p.evictIdle()
+	}
+	p.mu.Unlock()
+	if closed {
+		p.closeInstance(instance)
+	}
+	p.releaseSlot()
+}
+
+// drain closes the pool and hands back its idle instances, together with
+// the failures disposing instances that left it earlier. Leases released
+// later dispose their instance instead of returning it.
+func (p *instancePool) drain() ([]interface{}, []error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	instances := make([]interface{}, len(p.idle))
+	for i, idle := range p.idle {
+		instances[i] = idle.value
+	}
+	failures := p.failures
+	p.idle, p.failures = nil, nil
+	return instances, failures
+}
+
+func (p *instancePool) discard(instance interface{}) {
+	p.mu.Lock()
+	p.inUse--
+	p.mu.Unlock()
+	p.closeInstance(instance)
+	p.releaseSlot()
+}
+
+func (p *instancePool) releaseSlot() {
+	if p.slots != nil {
+		<-p.slots
+	}
+}
+
+func (p *instancePool) counts() (idle, inUse int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.idle), p.inUse
+}
+
+// closeInstance disposes an instance leaving the pool, keeping any failure
+// for Close to report.
+func (p *instancePool) closeInstance(instance interface{}) {
+	if err := dispose(instance); err != nil {
+		p.mu.Lock()
+		p.failures = append(p.failures, err)
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,189 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type TestConnection struct {
+	id     int
+	broken bool
+	closed bool
+}
+
+func (c *TestConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func registerConnections(t *testing.T, container *Container, config PoolConfig) *[]*TestConnection {
+	var created []*TestConnection
+	err := RegisterType[*TestConnection](container, func(*Container) *TestConnection {
+		conn := &TestConnection{id: len(created) + 1}
+		created = append(created, conn)
+		return conn
+	}, Pooled, WithPool(config))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	return &created
+}
+
+func TestPoolReusesReleasedInstances(t *testing.T) {
+	container := NewContainer()
+	created := registerConnections(t, container, PoolConfig{MaxSize: 2})
+
+	lease, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	lease.Release()
+	lease.Release()
+
+	lease, err = Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	if lease.Value.id != 1 || len(*created) != 1 {
+		t.Error("Released instance should be reused")
+	}
+}
+
+func TestPoolMaxSize(t *testing.T) {
+	container := NewContainer()
+	registerConnections(t, container, PoolConfig{MaxSize: 1})
+
+	lease, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := Acquire[*TestConnection](container, ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected acquire to wait for a free slot, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		lease.Release()
+	}()
+	second, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire released instance: %v", err)
+	}
+	if second.Value != lease.Value {
+		t.Error("Expected the released instance")
+	}
+}
+
+func TestPoolHealthCheckDiscardsBrokenInstances(t *testing.T) {
+	container := NewContainer()
+	created := registerConnections(t, container, PoolConfig{
+		HealthCheck: func(instance interface{}) error {
+			if instance.(*TestConnection).broken {
+				return errors.New("connection reset")
+			}
+			return nil
+		},
+	})
+
+	lease, _ := Acquire[*TestConnection](container, context.Background())
+	lease.Value.broken = true
+	lease.Release()
+
+	lease, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	if lease.Value.id != 2 {
+		t.Error("Broken instance should not be handed out")
+	}
+	if !(*created)[0].closed {
+		t.Error("Broken instance should be closed")
+	}
+}
+
+func TestPoolIdleEviction(t *testing.T) {
+	container := NewContainer()
+	created := registerConnections(t, container, PoolConfig{IdleTimeout: 10 * time.Millisecond})
+
+	lease, _ := Acquire[*TestConnection](container, context.Background())
+	lease.Release()
+	time.Sleep(20 * time.Millisecond)
+
+	lease, _ = Acquire[*TestConnection](container, context.Background())
+	if lease.Value.id != 2 || !(*created)[0].closed {
+		t.Error("Idle instance should be evicted after the timeout")
+	}
+}
+
+func TestPooledServiceCannotBeResolved(t *testing.T) {
+	container := NewContainer()
+	registerConnections(t, container, PoolConfig{})
+
+	_, err := container.Resolve((**TestConnection)(nil))
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+
+	err = container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{}
+	}, WithPool(PoolConfig{}))
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("WithPool should require the Pooled lifecycle, got %v", err)
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	container := NewContainer()
+	created := registerConnections(t, container, PoolConfig{})
+
+	idle, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	leased, err := Acquire[*TestConnection](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	idle.Release()
+
+	if err := container.Close(); err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if !(*created)[0].closed || (*created)[1].closed {
+		t.Error("Close should dispose idle pooled instances only")
+	}
+	leased.Release()
+	if !(*created)[1].closed {
+		t.Error("Instances released after Close should be disposed")
+	}
+
+	if _, err := Acquire[*TestConnection](container, context.Background()); ErrorCodeOf(err) != ErrCodeContainerClosed {
+		t.Errorf("Expected Acquire to fail after Close, got %v", err)
+	}
+}
+
+func TestPoolCloseReportsDiscardFailures(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterType[*failingCloser](container, func(*Container) *failingCloser {
+		return &failingCloser{}
+	}, Pooled, WithPool(PoolConfig{}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	lease, err := Acquire[*failingCloser](container, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire instance: %v", err)
+	}
+	lease.Discard()
+
+	if err := container.Close(); !errors.Is(err, errCloseFailed) {
+		t.Errorf("Close should report the failure disposing a discarded instance, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func Populate(resolver Resolver, target interface{}) error {
+	if c, ok := resolver.(*Container); ok && c.noReflection {
+		return newError(ErrCodeReflectionDisabled, nil, "field injection is disabled on containers created with WithNoReflection")
+	}
+
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return newError(ErrCodeInvalidArgument, nil, "populate target must be a non-nil pointer to a struct")
+	}
+
+	elem := value.Elem()
+	structType := elem.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() || field.Tag.Get("inject") == "-" {
+			continue
+		}
+
+		// Fields set by the caller take precedence over the container
+		if !elem.Field(i).IsZero() {
+			continue
+		}
+
+		dep, err := resolveValue(resolver, field.Type)
+		if err != nil {
+			return fmt.Errorf("failed to resolve field %s of %s: %w", field.Name, structType.String(), err)
+		}
+		elem.Field(i).Set(dep)
+	}
+	return nil
+}
+
+func MustPopulate(resolver Resolver, target interface{}) {
+	if err := Populate(resolver, target); err != nil {
+		panic(fmt.Sprintf("failed to populate %T: %v", target, err))
+	}
+}
+
+// New allocates a T, which must be a struct or a pointer to one, and fills
+// its fields with Populate. Nothing needs to be registered for T itself.
+func New[T any](resolver Resolver) (T, error) {
+	var zero T
+	t := reflect.TypeFor[T]()
+
+	pointer := t.Kind() == reflect.Ptr
+	structType := t
+	if pointer {
+		structType = t.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return zero, newError(ErrCodeInvalidArgument, t, "New requires a struct or a pointer to a struct, got %s", t.String())
+	}
+
+	value := reflect.New(structType)
+	if err := Populate(resolver, value.Interface()); err != nil {
+		return zero, err
+	}
+	if pointer {
+		return value.Interface().(T), nil
+	}
+	return value.Elem().Interface().(T), nil
+}
+
+func MustNew[T any](resolver Resolver) T {
+	result, err := New[T](resolver)
+	if err != nil {
+		panic(fmt.Sprintf("failed to construct %s: %v", reflect.TypeFor[T]().String(), err))
+	}
+	return result
+}
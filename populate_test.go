@@ -0,0 +1,127 @@
+package inject
+
+import (
+	"testing"
+)
+
+type TestResolverRoot struct {
+	Dependency TestInterface
+	Repository *TestRepository
+	Label      string `inject:"-"`
+	internal   *TestImplementation
+}
+
+func TestPopulate(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "populated"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	err = RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{data: make(map[string]string)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	root := &TestResolverRoot{}
+	if err := Populate(container, root); err != nil {
+		t.Fatalf("Failed to populate struct: %v", err)
+	}
+
+	if root.Dependency == nil || root.Dependency.GetValue() != "populated" {
+		t.Error("Interface field should be populated from the container")
+	}
+	if root.Repository == nil {
+		t.Error("Pointer field should be populated from the container")
+	}
+	if root.internal != nil {
+		t.Error("Unexported fields should be left untouched")
+	}
+}
+
+func TestPopulateKeepsPresetFields(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{data: make(map[string]string)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	preset := &TestImplementation{value: "preset"}
+	root := &TestResolverRoot{Dependency: preset}
+	if err := Populate(container, root); err != nil {
+		t.Fatalf("Failed to populate struct: %v", err)
+	}
+
+	if root.Dependency != preset {
+		t.Error("Populate should not overwrite fields that are already set")
+	}
+}
+
+func TestPopulateErrors(t *testing.T) {
+	container := NewContainer()
+
+	if err := Populate(container, TestResolverRoot{}); err == nil {
+		t.Error("Expected error when target is not a pointer")
+	}
+
+	if err := Populate(container, &TestResolverRoot{}); err == nil {
+		t.Error("Expected error when a field dependency is not registered")
+	}
+}
+
+func TestMustPopulatePanic(t *testing.T) {
+	container := NewContainer()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustPopulate should panic when a field cannot be resolved")
+		}
+	}()
+
+	MustPopulate(container, &TestResolverRoot{})
+}
+
+func TestNew(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "new"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+	err = RegisterSingletonType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{data: make(map[string]string)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	root, err := New[*TestResolverRoot](container)
+	if err != nil {
+		t.Fatalf("Failed to construct struct: %v", err)
+	}
+	if root.Dependency.GetValue() != "new" || root.Repository == nil {
+		t.Error("Fields should be filled from the container")
+	}
+
+	value := MustNew[TestResolverRoot](container)
+	if value.Dependency == nil || value.Label != "" {
+		t.Error("Struct values should be filled as well")
+	}
+
+	if _, err := New[string](container); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a non-struct type, got %v", err)
+	}
+	if _, err := New[*TestResolverRoot](NewContainer()); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED for missing fields, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package inject
+
+import (
+	"sort"
+)
+
+// profileRegistrar registers services that only take effect once their
+// profile is activated.
+type profileRegistrar struct {
+	container *Container
+	profile   string
+}
+
+var _ Registrar = profileRegistrar{}
+
+// Profile returns a Registrar whose registrations take effect only when
+// profile is active, so dev, staging and prod can bind different
+// implementations from the same wiring code. Any registration helper
+// accepts it:
+//
+//	inject.RegisterSingletonInterface[UserRepository, *MemoryUserRepository](c.Profile("dev"), NewMemoryUserRepository)
+//
+// Registrations made before the profile is activated are installed, in
+// order, when it is. Like any re-registration they replace what was
+// registered before, so register profile-specific services after the
+// defaults.
+func (c *Container) Profile(profile string) Registrar {
+	return profileRegistrar{container: c, profile: profile}
+}
+
+// RegisterWithProfile registers T for profile, see Profile.
+func RegisterWithProfile[T any](container *Container, profile string, factory func(*Container) T, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return RegisterType[T](container.Profile(profile), factory, lifecycle, opts...)
+}
+
+// ActivateProfiles activates profiles, installing the registrations made
+// for them so far. Later registrations for an active profile take effect
+// immediately.
+func (c *Container) ActivateProfiles(profiles ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.activeProfiles == nil {
+		c.activeProfiles = make(map[string]bool)
+	}
+	for _, profile := range profiles {
+		if c.activeProfiles[profile] {
+			continue
+		}
+		c.activeProfiles[profile] = true
+		for _, descriptor := range c.pendingProfiles[profile] {
+			c.install(descriptor)
+		}
+		delete(c.pendingProfiles, profile)
+	}
+}
+
+func (c *Container) ActiveProfiles() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	profiles := make([]string, 0, len(c.activeProfiles))
+	for profile := range c.activeProfiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+func (p profileRegistrar) Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return p.register(serviceType, factory, lifecycle, nil, opts)
+}
+
+func (p profileRegistrar) RegisterSingleton(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return p.Register(serviceType, factory, Singleton, opts...)
+}
+
+func (p profileRegistrar) RegisterTransient(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return p.Register(serviceType, factory, Transient, opts...)
+}
+
+func (p profileRegistrar) RegisterFunc(factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerFunc(p, factory, lifecycle, opts)
+}
+
+func (p profileRegistrar) register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) error {
+	c := p.container
+	c.mu.Lock()
+	if c.activeProfiles[p.profile] {
+		c.mu.Unlock()
+		return c.register(serviceType, factory, lifecycle, create, opts)
+	}
+	defer c.mu.Unlock()
+
+	descriptor, err := c.newDescriptor(serviceType, factory, lifecycle, create, opts)
+	if err != nil {
+		return err
+	}
+	if c.pendingProfiles == nil {
+		c.pendingProfiles = make(map[string][]*ServiceDescriptor)
+	}
+	c.pendingProfiles[p.profile] = append(c.pendingProfiles[p.profile], descriptor)
+	return nil
+}
@@ -0,0 +1,54 @@
+package inject
+
+import (
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "sql"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register default: %v", err)
+	}
+	err = RegisterSingletonInterface[TestInterface, *TestImplementation](container.Profile("dev"), func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "memory"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register dev implementation: %v", err)
+	}
+	err = RegisterWithProfile[*TestService](container, "staging", func(c *Container) *TestService {
+		return &TestService{}
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register staging service: %v", err)
+	}
+
+	if value := MustResolve[TestInterface](container).GetValue(); value != "sql" {
+		t.Errorf("Expected the default before activation, got '%s'", value)
+	}
+
+	container.ActivateProfiles("dev")
+	if value := MustResolve[TestInterface](container).GetValue(); value != "memory" {
+		t.Errorf("Expected the dev implementation once dev is active, got '%s'", value)
+	}
+	if container.Has((**TestService)(nil)) {
+		t.Error("Registrations of inactive profiles should not be visible")
+	}
+
+	err = RegisterWithProfile[*TestRepository](container, "dev", func(c *Container) *TestRepository {
+		return &TestRepository{}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register for an active profile: %v", err)
+	}
+	if !container.Has((**TestRepository)(nil)) {
+		t.Error("Registrations for an active profile should take effect immediately")
+	}
+
+	if profiles := container.ActiveProfiles(); len(profiles) != 1 || profiles[0] != "dev" {
+		t.Errorf("Expected [dev], got %v", profiles)
+	}
+}
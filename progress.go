@@ -0,0 +1,53 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type InitPhase int
+
+const (
+	InitStarted InitPhase = iota
+	InitCompleted
+	InitFailed
+)
+
+func (p InitPhase) String() string {
+	switch p {
+	case InitStarted:
+		return "Started"
+	case InitCompleted:
+		return "Completed"
+	case InitFailed:
+		return "Failed"
+	default:
+		return fmt.Sprintf("InitPhase(%d)", int(p))
+	}
+}
+
+// InitEvent reports the progress of one service during Warmup. Index counts
+// from 1 up to Total; Duration and Err are set once the service finished.
+type InitEvent struct {
+	ServiceType reflect.Type
+	Phase       InitPhase
+	Index       int
+	Total       int
+	Duration    time.Duration
+	Err         error
+}
+
+// WithInitProgress installs a callback invoked before and after each service
+// Warmup builds, for progress bars and structured startup logs.
+func WithInitProgress(fn func(InitEvent)) ContainerOption {
+	return func(c *Container) {
+		c.progress = fn
+	}
+}
+
+func (c *Container) reportProgress(event InitEvent) {
+	if c.progress != nil {
+		c.progress(event)
+	}
+}
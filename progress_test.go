@@ -0,0 +1,44 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWarmupProgress(t *testing.T) {
+	var events []string
+	container := NewContainer(WithInitProgress(func(event InitEvent) {
+		events = append(events, fmt.Sprintf("%d/%d %s %s", event.Index, event.Total, event.ServiceType, event.Phase))
+		if event.Phase == InitFailed && event.Err == nil {
+			t.Error("Failed events should carry the error")
+		}
+	}))
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() (TestInterface, error) {
+		return nil, errors.New("connection refused")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func() *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	container.Warmup(context.Background())
+
+	expected := []string{
+		"1/2 inject.TestInterface Started",
+		"1/2 inject.TestInterface Failed",
+		"2/2 inject.TestService Started",
+		"2/2 inject.TestService Completed",
+	}
+	if strings.Join(events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected progress events:\n%s", strings.Join(events, "\n"))
+	}
+}
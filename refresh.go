@@ -0,0 +1,133 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// addDependent records that dependent's factory resolved d. Edges are
+// learned at resolution time, so they also cover lookups made inside
+// factories through the container handle.
+func (d *ServiceDescriptor) addDependent(dependent reflect.Type) {
+	d.depMu.Lock()
+	defer d.depMu.Unlock()
+
+	if d.dependents == nil {
+		d.dependents = make(map[reflect.Type]struct{})
+	}
+	d.dependents[dependent] = struct{}{}
+}
+
+func (d *ServiceDescriptor) dependentTypes() []reflect.Type {
+	d.depMu.Lock()
+	defer d.depMu.Unlock()
+
+	types := make([]reflect.Type, 0, len(d.dependents))
+	for dependent := range d.dependents {
+		types = append(types, dependent)
+	}
+	return types
+}
+
+// recordDependency notes that the factory currently running on this handle
+// depends on descriptor.
+func (c *Container) recordDependency(descriptor *ServiceDescriptor) {
+	if c.frame != nil && !c.frame.done.Load() {
+		descriptor.addDependent(c.frame.serviceType)
+	}
+}
+
+// Refresh disposes the singleton T and builds it again, for example after
+// the settings it was built from have changed. Services that already hold
+// the old instance keep it; use RefreshWithDependents to rebuild them too.
+func Refresh[T any](container *Container) error {
+	return container.refresh(reflect.TypeFor[T](), false)
+}
+
+// RefreshWithDependents disposes and rebuilds the singleton T together with
+// every singleton that depends on it, directly or through other services, so
+// downstream clients pick up the new instance.
+func RefreshWithDependents[T any](container *Container) error {
+	return container.refresh(reflect.TypeFor[T](), true)
+}
+
+// refresh drops the cached instance of serviceType, and with dependents
+// that of every singleton depending on it, then builds the dropped
+// singletons again. Old instances are disposed like Close does.
+func (c *Container) refresh(serviceType reflect.Type, dependents bool) error {
+	c.mu.Lock()
+	descriptor, exists := c.services[serviceKey{serviceType: serviceType}]
+	if !exists {
+		defer c.mu.Unlock()
+		return c.notRegisteredError(serviceType)
+	}
+	if descriptor.Lifecycle != Singleton {
+		c.mu.Unlock()
+		return newError(ErrCodeInvalidArgument, serviceType, "only singletons can be refreshed, %s is %s", serviceType.String(), descriptor.Lifecycle)
+	}
+	dropped := c.dropCached(serviceKey{serviceType: serviceType}, dependents)
+	c.mu.Unlock()
+
+	errs := closeDropped(dropped)
+	for _, e := range dropped {
+		if _, err := c.resolve(e.serviceType); err != nil {
+			errs = append(errs, fmt.Errorf("failed to rebuild %s: %w", e.serviceType.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type droppedInstance struct {
+	serviceType reflect.Type
+	instance    interface{}
+	cleanup     func()
+}
+
+// dropCached drops the cached instance of the registration under key, and
+// with dependents that of every singleton depending on it, directly or
+// through other services, and returns them. Callers must hold c.mu.
+func (c *Container) dropCached(key serviceKey, dependents bool) []droppedInstance {
+	var dropped []droppedInstance
+	visited := map[serviceKey]bool{key: true}
+	queue := []serviceKey{key}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		descriptor, exists := c.services[current]
+		if !exists {
+			continue
+		}
+		if descriptor.Lifecycle == Singleton {
+			if instance, cleanup, _ := descriptor.dropInstance(); instance != nil {
+				dropped = append(dropped, droppedInstance{current.serviceType, instance, cleanup})
+				c.recordAudit(AuditRecord{
+					Action:      AuditEvict,
+					ServiceType: current.serviceType,
+					Lifecycle:   descriptor.Lifecycle,
+				})
+			}
+		}
+		if !dependents {
+			break
+		}
+		for _, dependent := range descriptor.dependentTypes() {
+			if next := (serviceKey{serviceType: dependent}); !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return dropped
+}
+
+func closeDropped(dropped []droppedInstance) []error {
+	var errs []error
+	for _, e := range dropped {
+		if err := closeEvicted(e.serviceType, e.instance, e.cleanup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,78 @@
+package inject
+
+import (
+	"strconv"
+	"testing"
+)
+
+func registerClientAndRepository(t *testing.T, container *Container) {
+	t.Helper()
+
+	builds := 0
+	err := RegisterSingletonType[*testClient](container, func(c *Container) *testClient {
+		builds++
+		return &testClient{dsn: "build " + strconv.Itoa(builds)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register client: %v", err)
+	}
+	err = RegisterSingletonType[*testRepository](container, func(c *Container) *testRepository {
+		return &testRepository{client: MustResolve[*testClient](c)}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	container := NewContainer()
+	registerClientAndRepository(t, container)
+
+	repository := MustResolve[*testRepository](container)
+	oldClient := repository.client
+
+	if err := Refresh[*testClient](container); err != nil {
+		t.Fatalf("Failed to refresh client: %v", err)
+	}
+	if !oldClient.closed {
+		t.Error("Refresh should close the old instance")
+	}
+	if client := MustResolve[*testClient](container); client == oldClient || client.dsn != "build 2" {
+		t.Errorf("Refresh should rebuild the singleton, got %q", client.dsn)
+	}
+	if MustResolve[*testRepository](container) != repository {
+		t.Error("Refresh should leave dependents alone")
+	}
+
+	if err := Refresh[*TestImplementation](container); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED, got %v", err)
+	}
+
+	err := RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	if err := Refresh[*TestImplementation](container); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for a transient, got %v", err)
+	}
+}
+
+func TestRefreshWithDependents(t *testing.T) {
+	container := NewContainer()
+	registerClientAndRepository(t, container)
+
+	repository := MustResolve[*testRepository](container)
+
+	if err := RefreshWithDependents[*testClient](container); err != nil {
+		t.Fatalf("Failed to refresh client: %v", err)
+	}
+	rebuilt := MustResolve[*testRepository](container)
+	if rebuilt == repository {
+		t.Error("RefreshWithDependents should rebuild dependents")
+	}
+	if rebuilt.client != MustResolve[*testClient](container) || rebuilt.client.dsn != "build 2" {
+		t.Error("Rebuilt dependents should hold the new instance")
+	}
+}
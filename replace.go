@@ -0,0 +1,74 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Replace swaps the registration of a service that is already registered,
+// for tests and plugins that substitute implementations after setup. The
+// cached instance of the old registration, and of every singleton built
+// from it, is dropped and disposed like Close does, so the next
+// resolutions use the replacement.
+//
+// Without WithReplacementGracePeriod the old instance is disposed at once,
+// even while callers that resolved it earlier are still using it. Replace
+// services under traffic on a container with a grace period, which closes
+// the old instance only once the period has passed.
+// Decorators and interceptors added to the old registration wrap the
+// replacement too.
+func (c *Container) Replace(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return c.replace(serviceType, factory, lifecycle, nil, opts)
+}
+
+// ReplaceType is the typed form of Replace, which also works on containers
+// created with WithNoReflection:
+//
+//	inject.ReplaceType[PaymentGateway](c, func(*inject.Container) PaymentGateway {
+//	    return &fakeGateway{}
+//	}, inject.Singleton)
+func ReplaceType[T any](c *Container, factory func(*Container) T, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	opts = append(opts[:len(opts):len(opts)], func(descriptor *ServiceDescriptor) {
+		descriptor.typed = &typedFactory[T]{factory: factory}
+	})
+	return c.replace((*T)(nil), factory, lifecycle, func(c *Container) (interface{}, error) {
+		return factory(c), nil
+	}, opts)
+}
+
+func (c *Container) replace(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) error {
+	c.mu.Lock()
+	descriptor, err := c.newDescriptor(serviceType, factory, lifecycle, create, opts)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	displaced, exists := c.services[descriptor.key()]
+	if !exists {
+		defer c.mu.Unlock()
+		return c.notRegisteredError(descriptor.ServiceType)
+	}
+	if c.checkDependencies {
+		if missing := c.missingDependencies(reflect.TypeOf(factory), descriptor.methodExpr); len(missing) > 0 {
+			c.mu.Unlock()
+			return newError(ErrCodeMissingDependency, descriptor.ServiceType, "factory for %s depends on unregistered service %s", descriptor.ServiceType.String(), missing[0].String())
+		}
+	}
+
+	var dropped []droppedInstance
+	if c.replaceGrace == 0 && displaced.Lifecycle == Singleton {
+		if instance, cleanup, _ := displaced.dropInstance(); instance != nil {
+			dropped = append(dropped, droppedInstance{displaced.ServiceType, instance, cleanup})
+		}
+	}
+	// Services built from the old registration depend on the replacement
+	for _, dependent := range displaced.dependentTypes() {
+		descriptor.addDependent(dependent)
+	}
+	descriptor.decorators = append(displaced.decorators[:len(displaced.decorators):len(displaced.decorators)], descriptor.decorators...)
+	c.install(descriptor)
+	dropped = append(dropped, c.dropCached(descriptor.key(), true)...)
+	c.mu.Unlock()
+
+	return errors.Join(closeDropped(dropped)...)
+}
@@ -0,0 +1,119 @@
+package inject
+
+import (
+	"testing"
+)
+
+type replaceConsumer struct {
+	closer *orderedCloser
+}
+
+func TestReplace(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	err := container.RegisterSingleton((**orderedCloser)(nil), func() *orderedCloser {
+		return &orderedCloser{name: "original", closed: &closed}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((**replaceConsumer)(nil), func(closer *orderedCloser) *replaceConsumer {
+		return &replaceConsumer{closer: closer}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register consumer: %v", err)
+	}
+	if MustResolve[*replaceConsumer](container).closer.name != "original" {
+		t.Fatal("Expected the original service before Replace")
+	}
+
+	err = container.Replace((**orderedCloser)(nil), func() *orderedCloser {
+		return &orderedCloser{name: "replacement", closed: &closed}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to replace service: %v", err)
+	}
+
+	if len(closed) != 1 || closed[0] != "original" {
+		t.Errorf("Expected the original instance to be closed, got %v", closed)
+	}
+	if name := MustResolve[*orderedCloser](container).name; name != "replacement" {
+		t.Errorf("Expected the replacement, got %s", name)
+	}
+	if name := MustResolve[*replaceConsumer](container).closer.name; name != "replacement" {
+		t.Errorf("Expected dependent singletons to be rebuilt with the replacement, got %s", name)
+	}
+
+	err = container.Replace((**TestService)(nil), func() *TestService { return &TestService{} }, Transient)
+	if ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected %s when replacing an unregistered service, got %v", ErrCodeNotRegistered, err)
+	}
+}
+
+type labelledService struct {
+	label string
+}
+
+func TestReplaceKeepsDecorators(t *testing.T) {
+	container := NewContainer(WithNoReflection())
+
+	err := RegisterSingletonType[*labelledService](container, func(c *Container) *labelledService {
+		return &labelledService{label: "original"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = Decorate[*labelledService](container, func(inner *labelledService, c *Container) *labelledService {
+		return &labelledService{label: "cached " + inner.label}
+	})
+	if err != nil {
+		t.Fatalf("Failed to decorate service: %v", err)
+	}
+
+	if err := container.Replace((**labelledService)(nil), func() *labelledService { return nil }, Singleton); ErrorCodeOf(err) != ErrCodeReflectionDisabled {
+		t.Errorf("Expected REFLECTION_DISABLED from Replace under WithNoReflection, got %v", err)
+	}
+	err = ReplaceType[*labelledService](container, func(c *Container) *labelledService {
+		return &labelledService{label: "replacement"}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to replace service: %v", err)
+	}
+	if label := MustResolve[*labelledService](container).label; label != "cached replacement" {
+		t.Errorf("Expected the decorator to wrap the replacement, got %q", label)
+	}
+}
+
+func TestReplaceNamedKeepsUnnamed(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	for _, name := range []string{"", "replica"} {
+		err := container.RegisterSingleton((**orderedCloser)(nil), func() *orderedCloser {
+			return &orderedCloser{name: "original " + name, closed: &closed}
+		}, WithName(name))
+		if err != nil {
+			t.Fatalf("Failed to register service: %v", err)
+		}
+	}
+	primary := MustResolve[*orderedCloser](container)
+	MustResolveNamed[*orderedCloser](container, "replica")
+
+	err := container.Replace((**orderedCloser)(nil), func() *orderedCloser {
+		return &orderedCloser{name: "replacement", closed: &closed}
+	}, Singleton, WithName("replica"))
+	if err != nil {
+		t.Fatalf("Failed to replace service: %v", err)
+	}
+
+	if len(closed) != 1 || closed[0] != "original replica" {
+		t.Errorf("Expected only the named instance to be closed, got %v", closed)
+	}
+	if MustResolve[*orderedCloser](container) != primary {
+		t.Error("Expected the unnamed singleton to stay cached")
+	}
+	if name := MustResolveNamed[*orderedCloser](container, "replica").name; name != "replacement" {
+		t.Errorf("Expected the named replacement, got %s", name)
+	}
+}
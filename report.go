@@ -0,0 +1,118 @@
+package inject
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+type ReportFormat string
+
+const (
+	ReportMarkdown ReportFormat = "markdown"
+	ReportHTML     ReportFormat = "html"
+)
+
+type reportService struct {
+	Type         string
+	Lifecycle    string
+	Owner        string
+	Description  string
+	Tags         []string
+	Dependencies []string
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Container report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>Container report</h1>
+<p>{{len .}} services</p>
+<table>
+<tr><th>Service</th><th>Lifecycle</th><th>Owner</th><th>Description</th><th>Tags</th><th>Dependencies</th></tr>
+{{range .}}<tr>
+<td><code>{{.Type}}</code></td>
+<td>{{.Lifecycle}}</td>
+<td>{{.Owner}}</td>
+<td>{{.Description}}</td>
+<td>{{range .Tags}}{{.}}<br>{{end}}</td>
+<td>{{range .Dependencies}}<code>{{.}}</code><br>{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Report documents every registration with its lifecycle, owner (the
+// "owner" metadata key), description, tags and dependencies, as Markdown or
+// HTML for architecture reviews.
+func (c *Container) Report(format ReportFormat) (string, error) {
+	services := c.reportServices()
+
+	switch format {
+	case ReportMarkdown:
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Container report\n\n%d services\n\n", len(services))
+		b.WriteString("| Service | Lifecycle | Owner | Description | Tags | Dependencies |\n")
+		b.WriteString("|---------|-----------|-------|-------------|------|--------------|\n")
+		for _, s := range services {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s | %s |\n",
+				s.Type, s.Lifecycle, markdownCell(s.Owner), markdownCell(s.Description),
+				markdownCell(strings.Join(s.Tags, ", ")), markdownCodeList(s.Dependencies))
+		}
+		return b.String(), nil
+	case ReportHTML:
+		var b strings.Builder
+		if err := reportTemplate.Execute(&b, services); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	default:
+		return "", newError(ErrCodeInvalidArgument, nil, "unsupported report format %q", format)
+	}
+}
+
+func (c *Container) reportServices() []reportService {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var services []reportService
+	for _, descriptor := range c.sortedDescriptors() {
+		service := reportService{
+			Type:        descriptor.displayName(),
+			Lifecycle:   descriptor.Lifecycle.String(),
+			Description: descriptor.Description,
+			Tags:        descriptor.Tags,
+		}
+		if owner, ok := descriptor.Metadata["owner"]; ok {
+			service.Owner = fmt.Sprint(owner)
+		}
+		for _, dep := range c.dependenciesOf(descriptor) {
+			service.Dependencies = append(service.Dependencies, dep.String())
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func markdownCodeList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,49 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	}, WithDescription("primary | replica"), WithMetadata("owner", "storage-team"), WithTags("critical"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	markdown, err := container.Report(ReportMarkdown)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	for _, expected := range []string{
+		"2 services",
+		"| `inject.TestInterface` | Singleton | storage-team | primary \\| replica | critical |  |",
+		"| `inject.TestService` | Transient |  |  |  | `inject.TestInterface` |",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected markdown report to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+
+	html, err := container.Report(ReportHTML)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if !strings.Contains(html, "<td>storage-team</td>") || !strings.Contains(html, "<code>inject.TestInterface</code><br>") {
+		t.Errorf("Unexpected HTML report:\n%s", html)
+	}
+
+	if _, err := container.Report("pdf"); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for an unknown format, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package inject
+
+type Resolver interface {
+	Resolve(serviceType interface{}) (interface{}, error)
+	Has(serviceType interface{}) bool
+}
+
+type Registrar interface {
+	Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error
+	RegisterSingleton(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error
+	RegisterTransient(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error
+	RegisterFunc(factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error
+}
+
+var (
+	_ Resolver  = (*Container)(nil)
+	_ Registrar = (*Container)(nil)
+	_ Resolver  = (*CompositeResolver)(nil)
+	_ Resolver  = readOnlyContainer{}
+)
+
+// readOnlyContainer hides the registration methods of a Container so the
+// view cannot be type-asserted back into something that mutates wiring.
+type readOnlyContainer struct {
+	container *Container
+}
+
+func (r readOnlyContainer) Resolve(serviceType interface{}) (interface{}, error) {
+	return r.container.Resolve(serviceType)
+}
+
+func (r readOnlyContainer) Has(serviceType interface{}) bool {
+	return r.container.Has(serviceType)
+}
+
+func (c *Container) ReadOnly() Resolver {
+	return readOnlyContainer{container: c}
+}
@@ -0,0 +1,76 @@
+package inject
+
+import (
+	"testing"
+)
+
+type recordingRegistrar struct {
+	registered map[string]Lifecycle
+}
+
+func (r *recordingRegistrar) Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	r.registered[serviceTypeOf(serviceType).String()] = lifecycle
+	return nil
+}
+
+func (r *recordingRegistrar) RegisterSingleton(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return r.Register(serviceType, factory, Singleton)
+}
+
+func (r *recordingRegistrar) RegisterTransient(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return r.Register(serviceType, factory, Transient)
+}
+
+func (r *recordingRegistrar) RegisterFunc(factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return nil
+}
+
+func TestRegistrationHelpersAcceptRegistrar(t *testing.T) {
+	registrar := &recordingRegistrar{registered: make(map[string]Lifecycle)}
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](registrar, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	err = RegisterTransientType[*TestService](registrar, func(c *Container) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register type: %v", err)
+	}
+
+	if registrar.registered["inject.TestInterface"] != Singleton {
+		t.Error("Interface should be registered as singleton through the Registrar")
+	}
+	if lifecycle, ok := registrar.registered["*inject.TestService"]; !ok || lifecycle != Transient {
+		t.Error("Type should be registered as transient through the Registrar")
+	}
+}
+
+func TestReadOnlyView(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "read only"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	view := container.ReadOnly()
+
+	if _, ok := view.(Registrar); ok {
+		t.Error("Read-only view should not expose registration methods")
+	}
+	if !view.Has((**TestImplementation)(nil)) {
+		t.Error("Read-only view should report registered services")
+	}
+
+	service := MustResolve[*TestImplementation](view)
+	if service.GetValue() != "read only" {
+		t.Error("Read-only view should resolve services from the container")
+	}
+}
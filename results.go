@@ -0,0 +1,134 @@
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Out marks a result object. When a constructor registered with
+// RegisterConstructor or RegisterFunc returns a struct embedding Out, each
+// of its exported fields is registered as a service of its own, so one
+// provider can supply several related services:
+//
+//	type DatabaseResults struct {
+//		inject.Out
+//
+//		DB       *sql.DB
+//		Migrator *Migrator
+//		Health   HealthCheck `name:"database"`
+//	}
+//
+//	inject.RegisterConstructor(c, NewDatabase, inject.Singleton)
+//
+// The struct itself is registered with the constructor's lifecycle and
+// options, and the fields follow its identity: they are read from the one
+// instance while it lives, and are disposed with it by Close. A field tagged
+// name is registered WithName, and one tagged group WithGroup.
+type Out struct{}
+
+var outType = reflect.TypeFor[Out]()
+
+// isResultStruct reports whether t is a struct embedding Out.
+func isResultStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// registerResults registers the result object returned by factory, then
+// each of its exported fields as a service reading it from the result.
+func registerResults(registrar Registrar, resultType reflect.Type, factory interface{}, lifecycle Lifecycle, opts []RegistrationOption) error {
+	// The fields read the result through its own registration, whatever
+	// name, group or sequence number it ends up with
+	var result *ServiceDescriptor
+	opts = append(opts[:len(opts):len(opts)], func(descriptor *ServiceDescriptor) {
+		result = descriptor
+	})
+	if err := registrar.Register(reflect.New(resultType).Interface(), factory, lifecycle, opts...); err != nil {
+		return err
+	}
+	resultKey := func() serviceKey {
+		if result == nil {
+			return serviceKey{serviceType: resultType}
+		}
+		return result.key()
+	}
+
+	for i := 0; i < resultType.NumField(); i++ {
+		field := resultType.Field(i)
+		if !field.IsExported() || field.Type == outType {
+			continue
+		}
+		if err := registerResultField(registrar, resultType, resultKey, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// disposeResultFields disposes the fields of a result object in reverse
+// order, since they are registered as views of it and owned by it.
+func disposeResultFields(result reflect.Value) error {
+	var errs []error
+	for i := result.NumField() - 1; i >= 0; i-- {
+		field := result.Type().Field(i)
+		if !field.IsExported() || field.Type == outType || result.Field(i).IsZero() {
+			continue
+		}
+		if err := dispose(result.Field(i).Interface()); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close field %s of %s: %w", field.Name, result.Type().String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func registerResultField(registrar Registrar, resultType reflect.Type, resultKey func() serviceKey, field reflect.StructField) error {
+	var opts []RegistrationOption
+	if name := field.Tag.Get("name"); name != "" {
+		opts = append(opts, WithName(name))
+	}
+	if group := field.Tag.Get("group"); group != "" {
+		opts = append(opts, WithGroup(group))
+	}
+
+	// The factory declares the dependency on an unnamed result object for
+	// Validate and Graph, and is only called by registrars without typed
+	// creation
+	var factory interface{}
+	if key := resultKey(); key.name == "" && key.group == "" {
+		factoryType := reflect.FuncOf([]reflect.Type{resultType}, []reflect.Type{field.Type}, false)
+		factory = reflect.MakeFunc(factoryType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{args[0].FieldByIndex(field.Index)}
+		}).Interface()
+	} else {
+		factoryType := reflect.FuncOf(nil, []reflect.Type{field.Type, reflect.TypeFor[error]()}, false)
+		factory = reflect.MakeFunc(factoryType, func([]reflect.Value) []reflect.Value {
+			err := newError(ErrCodeInvalidArgument, field.Type, "fields of the named or grouped result object %s are only available from a container", resultType.String())
+			return []reflect.Value{reflect.Zero(field.Type), reflect.ValueOf(&err).Elem()}
+		}).Interface()
+	}
+
+	serviceType := reflect.New(field.Type).Interface()
+	r, ok := registrar.(typedRegistrar)
+	if !ok {
+		return registrar.Register(serviceType, factory, Transient, opts...)
+	}
+	return r.register(serviceType, factory, Transient, func(c *Container) (interface{}, error) {
+		descriptor, exists := c.services[resultKey()]
+		if !exists {
+			return nil, dependencyError(resultType, c.notRegisteredError(resultType))
+		}
+		result, err := c.resolveDescriptor(descriptor)
+		if err != nil {
+			return nil, dependencyError(resultType, err)
+		}
+		return reflect.ValueOf(result).FieldByIndex(field.Index).Interface(), nil
+	}, opts)
+}
@@ -0,0 +1,118 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+type repositoryResults struct {
+	Out
+
+	Repository *TestRepository
+	Reader     TestInterface `name:"reader"`
+	internal   *TestService
+}
+
+func TestResultStruct(t *testing.T) {
+	container := NewContainer()
+
+	calls := 0
+	err := RegisterConstructor(container, func() repositoryResults {
+		calls++
+		return repositoryResults{
+			Repository: &TestRepository{data: map[string]string{}},
+			Reader:     &TestImplementation{value: "reader"},
+		}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+
+	repository := MustResolve[*TestRepository](container)
+	if repository != MustResolve[*TestRepository](container) {
+		t.Error("Fields should follow the lifecycle of the result object")
+	}
+	reader, err := ResolveNamed[TestInterface](container, "reader")
+	if err != nil {
+		t.Fatalf("Failed to resolve named field: %v", err)
+	}
+	if reader.GetValue() != "reader" {
+		t.Error("Fields tagged name should be registered under the name")
+	}
+	if calls != 1 {
+		t.Errorf("Constructor should run once for all fields, ran %d times", calls)
+	}
+	if container.Has((**TestService)(nil)) {
+		t.Error("Unexported fields should not be registered")
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Validate should accept result fields: %v", err)
+	}
+}
+
+func TestResultStructTransient(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterFunc(func() (repositoryResults, error) {
+		return repositoryResults{Repository: &TestRepository{}}, nil
+	}, Transient)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+	if MustResolve[*TestRepository](container) == MustResolve[*TestRepository](container) {
+		t.Error("Fields of a transient result object should be built per resolution")
+	}
+}
+
+func TestResultStructNamed(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterConstructor(container, func() repositoryResults {
+		return repositoryResults{Repository: &TestRepository{}}
+	}, Singleton, WithName("primary"))
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+
+	repository, err := container.Resolve((**TestRepository)(nil))
+	if err != nil {
+		t.Fatalf("Fields of a named result object should resolve: %v", err)
+	}
+	if repository != MustResolve[*TestRepository](container) {
+		t.Error("Fields should follow the identity of the named result object")
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Validate should accept fields of a named result object: %v", err)
+	}
+}
+
+type closerResults struct {
+	Out
+
+	Primary *orderedCloser `name:"primary"`
+	Replica *orderedCloser `name:"replica"`
+	Missing *orderedCloser `name:"missing"`
+}
+
+func TestResultStructFieldsClosed(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	err := RegisterConstructor(container, func() closerResults {
+		return closerResults{
+			Primary: &orderedCloser{name: "primary", closed: &closed},
+			Replica: &orderedCloser{name: "replica", closed: &closed},
+		}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register constructor: %v", err)
+	}
+
+	MustResolveNamed[*orderedCloser](container, "primary")
+	if err := container.Close(); err != nil {
+		t.Fatalf("Failed to close container: %v", err)
+	}
+	if strings.Join(closed, ",") != "replica,primary" {
+		t.Errorf("Expected the fields of the result object closed with it, got %v", closed)
+	}
+}
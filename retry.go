@@ -0,0 +1,152 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type RetryPolicy struct {
+	// InitialBackoff is how long a failed singleton construction is cached
+	// before the factory is tried again.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff; zero caps it at a day.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each consecutive failure; values
+	// below 1 are treated as 2.
+	Multiplier    float64
+	OnStateChange func(event ConstructionEvent)
+}
+
+type ConstructionState int
+
+const (
+	ConstructionFailed ConstructionState = iota
+	ConstructionRetrying
+	ConstructionRecovered
+)
+
+func (s ConstructionState) String() string {
+	switch s {
+	case ConstructionFailed:
+		return "Failed"
+	case ConstructionRetrying:
+		return "Retrying"
+	case ConstructionRecovered:
+		return "Recovered"
+	default:
+		return fmt.Sprintf("ConstructionState(%d)", int(s))
+	}
+}
+
+type ConstructionEvent struct {
+	ServiceType reflect.Type
+	State       ConstructionState
+	Attempt     int
+	Err         error
+	RetryAt     time.Time
+}
+
+type singletonFailure struct {
+	err      error
+	attempts int
+	retryAt  time.Time
+}
+
+func WithRetryPolicy(policy RetryPolicy) RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.retry = &policy
+	}
+}
+
+func WithDefaultRetryPolicy(policy RetryPolicy) ContainerOption {
+	return func(c *Container) {
+		c.retry = &policy
+	}
+}
+
+// defaultMaxBackoff caps the backoff of policies without MaxBackoff, before
+// it grows past what a time.Duration can hold.
+const defaultMaxBackoff = 24 * time.Hour
+
+func (p *RetryPolicy) backoff(attempts int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempts; i++ {
+		backoff *= multiplier
+		if backoff >= float64(maxBackoff) {
+			return maxBackoff
+		}
+	}
+	return min(time.Duration(backoff), maxBackoff)
+}
+
+func (p *RetryPolicy) notify(event ConstructionEvent) {
+	if p.OnStateChange != nil {
+		p.OnStateChange(event)
+	}
+}
+
+// constructSingleton builds a singleton, honouring the retry policy. Callers
+// must own the descriptor's in-flight construction.
+func (c *Container) constructSingleton(descriptor *ServiceDescriptor) (interface{}, error) {
+	policy := descriptor.retry
+	if policy == nil {
+		policy = c.retry
+	}
+	if policy == nil {
+		return c.construct(descriptor)
+	}
+
+	failure := descriptor.failure
+	if failure != nil {
+		if time.Now().Before(failure.retryAt) {
+			return nil, fmt.Errorf("%w (cached failure, next retry at %s)", failure.err, failure.retryAt.Format(time.RFC3339Nano))
+		}
+		policy.notify(ConstructionEvent{
+			ServiceType: descriptor.ServiceType,
+			State:       ConstructionRetrying,
+			Attempt:     failure.attempts + 1,
+			Err:         failure.err,
+		})
+	}
+
+	instance, err := c.construct(descriptor)
+	if err != nil {
+		attempts := 1
+		if failure != nil {
+			attempts = failure.attempts + 1
+		}
+		descriptor.failure = &singletonFailure{
+			err:      err,
+			attempts: attempts,
+			retryAt:  time.Now().Add(policy.backoff(attempts)),
+		}
+		policy.notify(ConstructionEvent{
+			ServiceType: descriptor.ServiceType,
+			State:       ConstructionFailed,
+			Attempt:     attempts,
+			Err:         err,
+			RetryAt:     descriptor.failure.retryAt,
+		})
+		return nil, err
+	}
+
+	if failure != nil {
+		descriptor.failure = nil
+		policy.notify(ConstructionEvent{
+			ServiceType: descriptor.ServiceType,
+			State:       ConstructionRecovered,
+			Attempt:     failure.attempts + 1,
+		})
+	}
+	return instance, nil
+}
@@ -0,0 +1,115 @@
+package inject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSingletonFailureIsCached(t *testing.T) {
+	container := NewContainer()
+
+	var events []ConstructionEvent
+	attempts := 0
+	err := container.RegisterSingleton((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("database down")
+		}
+		return &TestImplementation{value: "up"}, nil
+	}, WithRetryPolicy(RetryPolicy{
+		InitialBackoff: 30 * time.Millisecond,
+		OnStateChange: func(event ConstructionEvent) {
+			events = append(events, event)
+		},
+	}))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := container.Resolve((*TestImplementation)(nil)); err == nil {
+			t.Fatal("Expected cached failure")
+		}
+	}
+	if attempts != 1 {
+		t.Fatalf("Factory should not be retried while the failure is cached, ran %d times", attempts)
+	}
+
+	_, err = container.Resolve((*TestImplementation)(nil))
+	if !strings.Contains(err.Error(), "cached failure") || ErrorCodeOf(err) != ErrCodeFactoryError {
+		t.Errorf("Cached failure should keep the original error, got %v", err)
+	}
+
+	time.Sleep(35 * time.Millisecond)
+	container.Resolve((*TestImplementation)(nil))
+	if attempts != 2 {
+		t.Fatalf("Factory should be retried after the backoff, ran %d times", attempts)
+	}
+
+	// Second failure doubles the backoff
+	time.Sleep(35 * time.Millisecond)
+	if _, err := container.Resolve((*TestImplementation)(nil)); err == nil || attempts != 2 {
+		t.Fatalf("Backoff should grow after consecutive failures, ran %d times", attempts)
+	}
+
+	time.Sleep(35 * time.Millisecond)
+	service, err := container.Resolve((*TestImplementation)(nil))
+	if err != nil {
+		t.Fatalf("Expected recovery, got %v", err)
+	}
+	if service.(*TestImplementation).GetValue() != "up" {
+		t.Error("Recovered singleton should be returned")
+	}
+
+	var states []string
+	for _, event := range events {
+		states = append(states, event.State.String())
+	}
+	expected := "Failed,Retrying,Failed,Retrying,Recovered"
+	if strings.Join(states, ",") != expected {
+		t.Errorf("Expected state changes %s, got %s", expected, strings.Join(states, ","))
+	}
+	if events[2].Attempt != 2 || events[2].RetryAt.IsZero() {
+		t.Errorf("Failure events should carry attempt and retry time, got %+v", events[2])
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	container := NewContainer(WithDefaultRetryPolicy(RetryPolicy{InitialBackoff: time.Hour}))
+
+	attempts := 0
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		attempts++
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	TryResolve[*TestImplementation](container)
+	TryResolve[*TestImplementation](container)
+
+	if attempts != 1 {
+		t.Errorf("Container default policy should cache failures, ran %d times", attempts)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 3}
+
+	if policy.backoff(1) != time.Second {
+		t.Errorf("Expected 1s, got %s", policy.backoff(1))
+	}
+	if policy.backoff(2) != 3*time.Second {
+		t.Errorf("Expected 3s, got %s", policy.backoff(2))
+	}
+	if policy.backoff(3) != 5*time.Second {
+		t.Errorf("Expected backoff to be capped at 5s, got %s", policy.backoff(3))
+	}
+	uncapped := &RetryPolicy{InitialBackoff: time.Second}
+	if backoff := uncapped.backoff(200); backoff != defaultMaxBackoff {
+		t.Errorf("Expected backoff without MaxBackoff to stop at %s, got %s", defaultMaxBackoff, backoff)
+	}
+}
@@ -0,0 +1,64 @@
+package inject
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RoutesGroup is the group of Route that MountRoutes mounts.
+const RoutesGroup = "routes"
+
+// Route is an endpoint any package can contribute with RegisterRoute, so
+// new endpoints need no changes to main. Handler is a handler constructor
+// as taken by the Handler function.
+type Route struct {
+	Method  string
+	Path    string
+	Handler interface{}
+}
+
+// Pattern returns the http.ServeMux pattern of the route, such as
+// "GET /orders/{id}". Routes without a method match every method.
+func (r Route) Pattern() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return r.Method + " " + r.Path
+}
+
+// RegisterRoute contributes a route to RoutesGroup. The constructor is
+// checked now and called when the route is mounted:
+//
+//	inject.RegisterRoute(c, http.MethodGet, "/orders/{id}", NewOrderHandler)
+func RegisterRoute(container Registrar, method, path string, constructor interface{}) error {
+	if err := validateHandlerConstructor(constructor); err != nil {
+		return err
+	}
+	route := Route{Method: method, Path: path, Handler: constructor}
+	return RegisterGroup[Route](container, RoutesGroup, func(*Container) Route {
+		return route
+	})
+}
+
+// Mux is what MountRoutes registers handlers on, such as *http.ServeMux.
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// MountRoutes builds the handler of every route in RoutesGroup, resolving
+// the parameters of its constructor, and registers it on mux in the order
+// the routes were contributed.
+func MountRoutes(resolver Resolver, mux Mux) error {
+	routes, err := ResolveGroup[Route](resolver, RoutesGroup)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		handler, err := Handler(resolver, route.Handler)
+		if err != nil {
+			return fmt.Errorf("failed to mount route %s: %w", route.Pattern(), err)
+		}
+		mux.Handle(route.Pattern(), handler)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package inject
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMountRoutes(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonInterface[TestInterface, *TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{value: "order"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = RegisterRoute(container, http.MethodGet, "/orders/{id}", func(svc TestInterface) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(svc.GetValue() + " " + r.PathValue("id")))
+		})
+	})
+	if err != nil {
+		t.Fatalf("Failed to register route: %v", err)
+	}
+	err = RegisterRoute(container, "", "/health", func() http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register route: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	if err := MountRoutes(container, mux); err != nil {
+		t.Fatalf("Failed to mount routes: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+	if recorder.Body.String() != "order 7" {
+		t.Errorf("Expected 'order 7', got '%s'", recorder.Body.String())
+	}
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/orders/7", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/health", nil))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, recorder.Code)
+	}
+}
+
+func TestMountRoutesErrors(t *testing.T) {
+	container := NewContainer()
+
+	if err := RegisterRoute(container, http.MethodGet, "/", "not a function"); ErrorCodeOf(err) != ErrCodeInvalidFactory {
+		t.Errorf("Expected INVALID_FACTORY, got %v", err)
+	}
+
+	err := RegisterRoute(container, http.MethodGet, "/missing", func(svc TestInterface) http.Handler { return nil })
+	if err != nil {
+		t.Fatalf("Failed to register route: %v", err)
+	}
+	if err := MountRoutes(container, http.NewServeMux()); ErrorCodeOf(err) != ErrCodeNotRegistered {
+		t.Errorf("Expected NOT_REGISTERED mounting a route with a missing dependency, got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Scope caches one instance of each Scoped service, typically for the
+// lifetime of a request. Singletons are shared with the root container and
+// transients are built as usual. Singletons cannot depend on scoped
+// services, since they would keep the first scope's instance forever.
+type Scope struct {
+	container *Container
+	ctx       context.Context
+	mu        sync.Mutex
+	instances map[*ServiceDescriptor]*flight
+	// built holds the instances in creation order, for disposal in reverse
+	built  []scopedInstance
+	closed bool
+	// parent is the scope this one was detached from, and detached counts
+	// the scopes detached from this one that are still open, which keep
+	// its instances alive
+	parent   *Scope
+	detached int
+	work     unitOfWork
+}
+
+type scopedInstance struct {
+	serviceType reflect.Type
+	instance    interface{}
+	cleanup     func()
+}
+
+var _ Resolver = (*Scope)(nil)
+
+// NewScope returns a scope resolving from the container. Close it when it
+// ends to dispose its instances.
+func (c *Container) NewScope() *Scope {
+	return c.NewScopeWithContext(context.Background())
+}
+
+// NewScopeWithContext returns a scope whose factories receive ctx, with the
+// scope attached, for their context.Context parameters.
+func (c *Container) NewScopeWithContext(ctx context.Context) *Scope {
+	s := &Scope{instances: make(map[*ServiceDescriptor]*flight)}
+	s.container = &Container{containerCore: c.containerCore, scope: s}
+	s.ctx = WithScope(ctx, s)
+	return s
+}
+
+// Context returns the scope's context, which carries the scope.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+func (s *Scope) Resolve(serviceType interface{}) (interface{}, error) {
+	return s.container.Resolve(serviceType)
+}
+
+func (s *Scope) Has(serviceType interface{}) bool {
+	return s.container.Has(serviceType)
+}
+
+// Container returns a handle that resolves within the scope, for code that
+// takes a *Container.
+func (s *Scope) Container() *Container {
+	return s.container
+}
+
+// Detach returns a scope for work that outlives s, such as a goroutine
+// spawned by a request handler. It reuses the instances s has already
+// built, which are disposed only once s and every scope detached from it
+// are closed, and builds others itself. Its context carries the values of
+// the context of s, but is not canceled with it:
+//
+//	background := scope.Detach()
+//	go func() {
+//	    defer background.Close()
+//	    sendReceipt(background.Context(), inject.MustResolve[*Mailer](background))
+//	}()
+func (s *Scope) Detach() *Scope {
+	d := &Scope{instances: make(map[*ServiceDescriptor]*flight)}
+	d.container = &Container{containerCore: s.container.containerCore, scope: d}
+	d.ctx = WithScope(context.WithoutCancel(s.ctx), d)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The instances of a scope that is already disposed cannot be shared
+	if s.instances != nil {
+		d.parent = s
+		s.detached++
+	}
+	return d
+}
+
+// shared returns the instance of descriptor built by a scope s was
+// detached from, if any.
+func (s *Scope) shared(descriptor *ServiceDescriptor) (interface{}, bool) {
+	for parent := s.parent; parent != nil; parent = parent.parent {
+		parent.mu.Lock()
+		f, ok := parent.instances[descriptor]
+		parent.mu.Unlock()
+		if ok {
+			<-f.done
+			if f.err == nil {
+				return f.instance, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s *Scope) resolve(c *Container, descriptor *ServiceDescriptor) (interface{}, bool, error) {
+	if instance, ok := s.shared(descriptor); ok {
+		return instance, true, nil
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, false, newError(ErrCodeScopeClosed, descriptor.ServiceType, "scope is closed")
+	}
+	if f, ok := s.instances[descriptor]; ok {
+		s.mu.Unlock()
+		<-f.done
+		return f.instance, true, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	s.instances[descriptor] = f
+	s.mu.Unlock()
+
+	f.instance, f.err = c.construct(descriptor)
+
+	s.mu.Lock()
+	closed := s.closed
+	switch {
+	case f.err != nil:
+		delete(s.instances, descriptor)
+	case !closed:
+		s.built = append(s.built, scopedInstance{descriptor.ServiceType, f.instance, f.cleanup})
+	}
+	s.mu.Unlock()
+	if f.err == nil && closed {
+		// The scope was closed while the instance was being built; nothing
+		// would dispose it later
+		f.err = newError(ErrCodeScopeClosed, descriptor.ServiceType, "scope is closed")
+		if err := dispose(f.instance); err != nil {
+			f.err = errors.Join(f.err, fmt.Errorf("failed to close scoped %s: %w", descriptor.ServiceType.String(), err))
+		}
+		f.instance = nil
+	}
+	close(f.done)
+	if f.err != nil {
+		runCleanup(f.cleanup)
+	}
+	return f.instance, false, f.err
+}
+
+// Close ends the scope, disposing the scoped instances that implement
+// Shutdowner or io.Closer in the reverse order of their creation. Later
+// resolutions of scoped services from the scope fail. The commit or
+// rollback hooks run first, see Complete. While scopes detached from it are
+// open, its instances are disposed when the last of them is closed instead.
+func (s *Scope) Close() error {
+	var errs []error
+	s.close(func(serviceType reflect.Type, err error) {
+		errs = append(errs, err)
+	})
+	return errors.Join(errs...)
+}
+
+// close ends the scope like Close, handing each disposal failure to fail.
+func (s *Scope) close(fail func(serviceType reflect.Type, err error)) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	work := s.work
+	built, disposed := s.takeBuilt()
+	s.mu.Unlock()
+
+	work.finish(s.ctx, fail)
+	if disposed {
+		s.dispose(built, fail)
+	}
+}
+
+// release drops the hold of a detached scope that has been disposed.
+func (s *Scope) release(fail func(serviceType reflect.Type, err error)) {
+	s.mu.Lock()
+	s.detached--
+	built, disposed := s.takeBuilt()
+	s.mu.Unlock()
+
+	if disposed {
+		s.dispose(built, fail)
+	}
+}
+
+// takeBuilt hands over the instances to dispose once the scope is closed
+// and no detached scope holds it. Callers must hold s.mu.
+func (s *Scope) takeBuilt() ([]scopedInstance, bool) {
+	if !s.closed || s.detached > 0 || s.instances == nil {
+		return nil, false
+	}
+	built := s.built
+	s.built, s.instances = nil, nil
+	return built, true
+}
+
+func (s *Scope) dispose(built []scopedInstance, fail func(serviceType reflect.Type, err error)) {
+	for i := len(built) - 1; i >= 0; i-- {
+		if err := dispose(built[i].instance); err != nil {
+			fail(built[i].serviceType, fmt.Errorf("failed to close scoped %s: %w", built[i].serviceType.String(), err))
+		}
+		runCleanup(built[i].cleanup)
+	}
+	if s.parent != nil {
+		s.parent.release(fail)
+	}
+}
+
+// scopeMismatch explains why a scoped service cannot be resolved from a
+// handle without a scope: a singleton under construction would capture it,
+// or it was resolved from the container itself.
+func (c *Container) scopeMismatch(serviceType reflect.Type) error {
+	for frame := c.frame; frame != nil && !frame.done.Load(); frame = frame.parent {
+		if frame.descriptor != nil && frame.descriptor.Lifecycle == Singleton {
+			return newError(ErrCodeLifetimeMismatch, serviceType, "singleton %s cannot depend on scoped service %s, it would keep the first scope's instance", frame.serviceType.String(), serviceType.String())
+		}
+	}
+	return newError(ErrCodeLifetimeMismatch, serviceType, "service of type %s is scoped; resolve it from a scope created with NewScope", serviceType.String())
+}
+
+var contextType = reflect.TypeFor[context.Context]()
+
+type scopeKey struct{}
+
+// WithScope returns a copy of ctx carrying scope, so code further down the
+// call chain can resolve from it with ScopeFromContext.
+func WithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(*Scope)
+	return scope, ok
+}
+
+// context is the context.Context given to factory parameters: the scope's
+// context within a scope, and context.Background otherwise.
+func (c *Container) context() context.Context {
+	if c.scope != nil {
+		return c.scope.ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,310 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type requestContext struct {
+	id     int
+	closed *[]string
+}
+
+func (r *requestContext) Close() error {
+	*r.closed = append(*r.closed, "request")
+	return nil
+}
+
+type requestHandler struct {
+	request *requestContext
+	repo    *TestRepository
+	closed  *[]string
+}
+
+func (h *requestHandler) Close() error {
+	*h.closed = append(*h.closed, "handler")
+	return nil
+}
+
+func TestScopedLifecycle(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	var requests int
+	err := container.Register((**requestContext)(nil), func() *requestContext {
+		requests++
+		return &requestContext{id: requests, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	err = container.RegisterSingleton((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register singleton: %v", err)
+	}
+	err = container.Register((**requestHandler)(nil), func(request *requestContext, repo *TestRepository) *requestHandler {
+		return &requestHandler{request: request, repo: repo, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped handler: %v", err)
+	}
+
+	first := container.NewScope()
+	handler, err := first.Resolve((**requestHandler)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped handler: %v", err)
+	}
+	request, err := first.Resolve((**requestContext)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+	if handler.(*requestHandler).request != request {
+		t.Error("Expected one instance per scope")
+	}
+
+	second := container.NewScope()
+	other, err := second.Resolve((**requestHandler)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped handler: %v", err)
+	}
+	if other.(*requestHandler).request == request {
+		t.Error("Expected separate instances in separate scopes")
+	}
+	if other.(*requestHandler).repo != handler.(*requestHandler).repo {
+		t.Error("Expected singletons to be shared with the root")
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if strings.Join(closed, ",") != "handler,request" {
+		t.Errorf("Expected scoped instances closed in reverse creation order, got %v", closed)
+	}
+	if _, err := first.Resolve((**requestContext)(nil)); ErrorCodeOf(err) != ErrCodeScopeClosed {
+		t.Errorf("Expected SCOPE_CLOSED from a closed scope, got %v", err)
+	}
+	if err := second.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+}
+
+func TestScopedRequiresScope(t *testing.T) {
+	container := NewContainer()
+
+	err := container.Register((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func(repo *TestRepository) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register singleton: %v", err)
+	}
+
+	if _, err := container.Resolve((**TestRepository)(nil)); ErrorCodeOf(err) != ErrCodeLifetimeMismatch || !strings.Contains(err.Error(), "NewScope") {
+		t.Errorf("Expected LIFETIME_MISMATCH suggesting NewScope resolving a scoped service from the root, got %v", err)
+	}
+
+	scope := container.NewScope()
+	defer scope.Close()
+	if _, err := scope.Resolve((*TestService)(nil)); ErrorCodeOf(err) != ErrCodeLifetimeMismatch || !strings.Contains(err.Error(), "singleton inject.TestService") {
+		t.Errorf("Expected a singleton to be unable to capture a scoped service, got %v", err)
+	}
+}
+
+func TestScopeFactoriesResolveWithinScope(t *testing.T) {
+	container := NewContainer()
+
+	err := container.Register((**TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{data: map[string]string{}}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	err = RegisterTransientType[*TestService](container, func(c *Container) *TestService {
+		repo, _ := c.Resolve((**TestRepository)(nil))
+		return &TestService{dependency: &TestImplementation{value: repo.(*TestRepository).Get("key")}}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	scope := container.NewScope()
+	defer scope.Close()
+	repo, err := scope.Resolve((**TestRepository)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+	repo.(*TestRepository).Set("key", "scoped")
+
+	service := MustResolve[*TestService](scope)
+	if service.GetDependency().GetValue() != "scoped" {
+		t.Error("Expected a factory's container to resolve within the scope")
+	}
+}
+
+func TestScopedFailuresAreNotCached(t *testing.T) {
+	container := NewContainer()
+
+	calls := 0
+	err := container.Register((**TestRepository)(nil), func() (*TestRepository, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("not yet")
+		}
+		return &TestRepository{}, nil
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+
+	scope := container.NewScope()
+	defer scope.Close()
+	if _, err := scope.Resolve((**TestRepository)(nil)); err == nil {
+		t.Fatal("Expected the first resolution to fail")
+	}
+	if _, err := scope.Resolve((**TestRepository)(nil)); err != nil {
+		t.Errorf("Expected a failed scoped construction to be retried, got %v", err)
+	}
+}
+
+type requestIDKey struct{}
+
+func TestScopeContext(t *testing.T) {
+	container := NewContainer(WithDependencyChecks())
+
+	var closed []string
+	err := container.Register((**requestContext)(nil), func(ctx context.Context) *requestContext {
+		id, _ := ctx.Value(requestIDKey{}).(int)
+		return &requestContext{id: id, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	err = container.RegisterSingleton((**TestRepository)(nil), func(ctx context.Context) *TestRepository {
+		if _, ok := ScopeFromContext(ctx); ok {
+			t.Error("Expected singletons not to receive a scope's context")
+		}
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register singleton: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, 42)
+	scope := container.NewScopeWithContext(ctx)
+	defer scope.Close()
+
+	request, err := scope.Resolve((**requestContext)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve scoped service: %v", err)
+	}
+	if request.(*requestContext).id != 42 {
+		t.Error("Expected the factory to receive the scope's context")
+	}
+	if _, err := scope.Resolve((**TestRepository)(nil)); err != nil {
+		t.Fatalf("Failed to resolve singleton: %v", err)
+	}
+
+	fromContext, ok := ScopeFromContext(scope.Context())
+	if !ok || fromContext != scope {
+		t.Error("Expected the scope's context to carry the scope")
+	}
+	if _, ok := ScopeFromContext(context.Background()); ok {
+		t.Error("Expected no scope in a plain context")
+	}
+
+	other := container.NewScope()
+	defer other.Close()
+	if scoped, _ := ScopeFromContext(WithScope(ctx, other)); scoped != other {
+		t.Error("Expected WithScope to attach the scope")
+	}
+}
+
+func TestScopeClosedDuringConstruction(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	started, release := make(chan struct{}), make(chan struct{})
+	err := container.Register((**shutdownRecorder)(nil), func() *shutdownRecorder {
+		close(started)
+		<-release
+		return &shutdownRecorder{closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+
+	scope := container.NewScope()
+	done := make(chan error)
+	go func() {
+		_, err := scope.Resolve((**shutdownRecorder)(nil))
+		done <- err
+	}()
+	<-started
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	close(release)
+
+	if err := <-done; ErrorCodeOf(err) != ErrCodeScopeClosed {
+		t.Errorf("Expected SCOPE_CLOSED for an instance finished after Close, got %v", err)
+	}
+	if strings.Join(closed, ",") != "shutdown" {
+		t.Errorf("Expected the late instance to be shut down right away, got %v", closed)
+	}
+}
+
+func TestScopeDetach(t *testing.T) {
+	container := NewContainer()
+
+	var closed []string
+	var requests int
+	err := container.Register((**requestContext)(nil), func() *requestContext {
+		requests++
+		return &requestContext{id: requests, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped service: %v", err)
+	}
+	err = container.Register((**requestHandler)(nil), func(request *requestContext) *requestHandler {
+		return &requestHandler{request: request, closed: &closed}
+	}, Scoped)
+	if err != nil {
+		t.Fatalf("Failed to register scoped handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scope := container.NewScopeWithContext(ctx)
+	request := MustResolve[*requestContext](scope)
+	detached := scope.Detach()
+
+	cancel()
+	if err := scope.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if len(closed) != 0 {
+		t.Errorf("Expected the detached scope to keep the instances alive, got %v", closed)
+	}
+	if detached.Context().Err() != nil {
+		t.Error("Expected the detached context not to be canceled with the request")
+	}
+
+	handler := MustResolve[*requestHandler](detached)
+	if handler.request != request || requests != 1 {
+		t.Error("Expected the detached scope to reuse the instances already built")
+	}
+	if err := detached.Close(); err != nil {
+		t.Fatalf("Failed to close detached scope: %v", err)
+	}
+	if strings.Join(closed, ",") != "handler,request" {
+		t.Errorf("Expected every instance to be closed with the last scope, got %v", closed)
+	}
+}
@@ -0,0 +1,108 @@
+package inject
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ServiceStats struct {
+	ServiceType   reflect.Type
+	Name          string
+	Lifecycle     Lifecycle
+	Created       uint64
+	Failures      uint64
+	LastError     error
+	LastErrorTime time.Time
+	Degraded      bool
+	DegradedBy    error
+	// Waits counts resolutions that waited for another goroutine to finish
+	// building the singleton, and WaitTime is the total time they waited.
+	Waits    uint64
+	WaitTime time.Duration
+	// ApproxBytes is the heap allocated while building the singleton when
+	// the container was created WithMemoryAccounting.
+	ApproxBytes uint64
+}
+
+type ContainerStats struct {
+	Registrations int
+	Services      []ServiceStats
+}
+
+type serviceStats struct {
+	created       atomic.Uint64
+	failures      atomic.Uint64
+	waits         atomic.Uint64
+	waitNanos     atomic.Int64
+	approxBytes   atomic.Uint64
+	mu            sync.Mutex
+	lastError     error
+	lastErrorTime time.Time
+	degradedBy    error
+}
+
+func (c *Container) Stats() ContainerStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := ContainerStats{Registrations: len(c.services)}
+	for _, descriptor := range append(c.sortedDescriptors(), c.genericInstances()...) {
+		stats.Services = append(stats.Services, descriptor.snapshotStats())
+	}
+	return stats
+}
+
+func (d *ServiceDescriptor) snapshotStats() ServiceStats {
+	d.stats.mu.Lock()
+	defer d.stats.mu.Unlock()
+
+	return ServiceStats{
+		ServiceType:   d.ServiceType,
+		Name:          d.Name,
+		Lifecycle:     d.Lifecycle,
+		Created:       d.stats.created.Load(),
+		Failures:      d.stats.failures.Load(),
+		LastError:     d.stats.lastError,
+		LastErrorTime: d.stats.lastErrorTime,
+		Degraded:      d.stats.degradedBy != nil,
+		DegradedBy:    d.stats.degradedBy,
+		Waits:         d.stats.waits.Load(),
+		WaitTime:      time.Duration(d.stats.waitNanos.Load()),
+		ApproxBytes:   d.stats.approxBytes.Load(),
+	}
+}
+
+func (s ContainerStats) DegradedServices() []ServiceStats {
+	var degraded []ServiceStats
+	for _, service := range s.Services {
+		if service.Degraded {
+			degraded = append(degraded, service)
+		}
+	}
+	return degraded
+}
+
+// setDegraded records the primary failure that made the container fall back
+// to the degraded factory, or clears it when err is nil.
+func (s *serviceStats) setDegraded(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.degradedBy = err
+}
+
+func (s *serviceStats) recordWait(d time.Duration) {
+	s.waits.Add(1)
+	s.waitNanos.Add(int64(d))
+}
+
+func (c *Container) factoryFailed(descriptor *ServiceDescriptor, err error) {
+	descriptor.stats.mu.Lock()
+	descriptor.stats.failures.Add(1)
+	descriptor.stats.lastError = err
+	descriptor.stats.lastErrorTime = time.Now()
+	descriptor.stats.mu.Unlock()
+
+	c.report(descriptor.ServiceType, err, false)
+}
@@ -0,0 +1,75 @@
+package inject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStatsCountsFactoryFailures(t *testing.T) {
+	container := NewContainer()
+
+	attempts := 0
+	err := container.RegisterSingleton((*TestImplementation)(nil), func() (*TestImplementation, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("dial tcp: connection refused")
+		}
+		return &TestImplementation{value: "connected"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = RegisterTransientType[*TestRepository](container, func(c *Container) *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register repository: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		container.Resolve((*TestImplementation)(nil))
+	}
+	MustResolve[*TestRepository](container)
+	MustResolve[*TestRepository](container)
+
+	stats := container.Stats()
+	if stats.Registrations != 2 || len(stats.Services) != 2 {
+		t.Fatalf("Expected stats for 2 registrations, got %+v", stats)
+	}
+
+	repo, impl := stats.Services[0], stats.Services[1]
+	if repo.Created != 2 || repo.Failures != 0 || repo.LastError != nil {
+		t.Errorf("Unexpected repository stats: %+v", repo)
+	}
+
+	if impl.Failures != 2 {
+		t.Errorf("Expected 2 failures, got %d", impl.Failures)
+	}
+	if impl.Created != 1 {
+		t.Errorf("Singleton should be created once after recovering, got %d", impl.Created)
+	}
+	if impl.LastError == nil || impl.LastError.Error() != "dial tcp: connection refused" {
+		t.Errorf("Expected last error to be recorded, got %v", impl.LastError)
+	}
+	if impl.LastErrorTime.IsZero() {
+		t.Error("Last error time should be recorded")
+	}
+}
+
+func TestStatsCountsPanics(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterTransientType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		panic("broken")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	TryResolve[*TestImplementation](container)
+
+	if failures := container.Stats().Services[0].Failures; failures != 1 {
+		t.Errorf("Expected panics to count as failures, got %d", failures)
+	}
+}
@@ -0,0 +1,58 @@
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+func (c *Container) notRegisteredError(serviceType reflect.Type) error {
+	suggestions := c.suggestTypes(serviceType)
+	if len(suggestions) == 0 {
+		return newError(ErrCodeNotRegistered, serviceType, "service of type %s not registered", serviceType.String())
+	}
+	return newError(ErrCodeNotRegistered, serviceType, "service of type %s not registered (did you mean %s?)", serviceType.String(), strings.Join(suggestions, ", "))
+}
+
+// suggestTypes lists registered types that look like near misses for the
+// requested one. Callers must hold c.mu.
+func (c *Container) suggestTypes(serviceType reflect.Type) []string {
+	var suggestions []string
+	for _, descriptor := range c.sortedDescriptors() {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		if reason := nearMiss(serviceType, descriptor.ServiceType); reason != "" {
+			suggestions = append(suggestions, fmt.Sprintf("%s (%s)", descriptor.ServiceType.String(), reason))
+		}
+	}
+	return suggestions
+}
+
+func nearMiss(requested, registered reflect.Type) string {
+	if registered == reflect.PointerTo(requested) {
+		return "registered as a pointer"
+	}
+	if requested.Kind() == reflect.Ptr && registered == requested.Elem() {
+		return "registered as a value"
+	}
+
+	if requested.Kind() == reflect.Interface && registered.Kind() != reflect.Interface && registered.Implements(requested) {
+		return "implements the interface but is not registered as it"
+	}
+
+	requestedBase, registeredBase := baseType(requested), baseType(registered)
+	if requestedBase.Name() != "" && requestedBase.Name() == registeredBase.Name() && requestedBase.PkgPath() != registeredBase.PkgPath() {
+		return fmt.Sprintf("same name in package %s", registeredBase.PkgPath())
+	}
+	return ""
+}
+
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
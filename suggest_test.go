@@ -0,0 +1,83 @@
+package inject
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type Request struct{}
+
+func TestSuggestionPointerVersusValue(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestImplementation)(nil))
+	if err == nil {
+		t.Fatal("Expected error when resolving the value type")
+	}
+	if !strings.Contains(err.Error(), "did you mean *inject.TestImplementation (registered as a pointer)?") {
+		t.Errorf("Expected pointer suggestion, got '%s'", err.Error())
+	}
+}
+
+func TestSuggestionValueVersusPointer(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestRepository)(nil), func() *TestRepository {
+		return &TestRepository{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((**TestRepository)(nil))
+	if err == nil || !strings.Contains(err.Error(), "inject.TestRepository (registered as a value)") {
+		t.Errorf("Expected value suggestion, got %v", err)
+	}
+}
+
+func TestSuggestionImplementation(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterSingletonType[*TestImplementation](container, func(c *Container) *TestImplementation {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	_, err = container.Resolve((*TestInterface)(nil))
+	if err == nil || !strings.Contains(err.Error(), "*inject.TestImplementation (implements the interface") {
+		t.Errorf("Expected implementation suggestion, got %v", err)
+	}
+}
+
+func TestSuggestionSameNameOtherPackage(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterValue[*http.Request](container, &http.Request{})
+	if err != nil {
+		t.Fatalf("Failed to register request: %v", err)
+	}
+
+	_, err = container.Resolve((*Request)(nil))
+	if err == nil || !strings.Contains(err.Error(), "*http.Request (same name in package net/http)") {
+		t.Errorf("Expected same-name suggestion, got %v", err)
+	}
+}
+
+func TestNoSuggestions(t *testing.T) {
+	container := NewContainer()
+
+	_, err := container.Resolve((*TestService)(nil))
+	if err == nil || err.Error() != "service of type inject.TestService not registered" {
+		t.Errorf("Expected plain error without suggestions, got %v", err)
+	}
+}
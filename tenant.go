@@ -0,0 +1,75 @@
+package inject
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type tenantKey struct{}
+
+type tenantBinding struct {
+	tenant   string
+	resolver Resolver
+}
+
+// TenantExtractor picks the tenant identifier out of a request, e.g. from a
+// header, the host name or a verified token claim.
+type TenantExtractor func(r *http.Request) (string, error)
+
+func TenantFromHeader(name string) TenantExtractor {
+	return func(r *http.Request) (string, error) {
+		if tenant := r.Header.Get(name); tenant != "" {
+			return tenant, nil
+		}
+		return "", fmt.Errorf("missing %s header", name)
+	}
+}
+
+// TenantFromHost uses the first label of the request host, so
+// "acme.example.com" selects the tenant "acme".
+func TenantFromHost() TenantExtractor {
+	return func(r *http.Request) (string, error) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if tenant, _, found := strings.Cut(host, "."); found && tenant != "" {
+			return tenant, nil
+		}
+		return "", fmt.Errorf("host %s has no tenant subdomain", r.Host)
+	}
+}
+
+// TenantMiddleware binds the tenant of each request and the resolver
+// returned by lookup for it into the request context, where handlers find
+// them with TenantFromContext. Requests without a tenant are rejected with
+// 400 and unknown tenants with 404.
+func TenantMiddleware(extract TenantExtractor, lookup func(tenant string) (Resolver, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, err := extract(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			resolver, err := lookup(tenant)
+			if err != nil {
+				http.Error(w, "unknown tenant "+tenant, http.StatusNotFound)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), tenant, resolver)))
+		})
+	}
+}
+
+func WithTenant(ctx context.Context, tenant string, resolver Resolver) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantBinding{tenant: tenant, resolver: resolver})
+}
+
+func TenantFromContext(ctx context.Context) (string, Resolver, bool) {
+	binding, ok := ctx.Value(tenantKey{}).(tenantBinding)
+	return binding.tenant, binding.resolver, ok
+}
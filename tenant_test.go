@@ -0,0 +1,77 @@
+package inject
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantMiddleware(t *testing.T) {
+	tenants := make(map[string]Resolver)
+	for _, name := range []string{"acme", "globex"} {
+		container := NewContainer()
+		value := name
+		err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+			return &TestImplementation{value: value}
+		})
+		if err != nil {
+			t.Fatalf("Failed to register service: %v", err)
+		}
+		tenants[name] = container
+	}
+
+	middleware := TenantMiddleware(TenantFromHeader("X-Tenant"), func(tenant string) (Resolver, error) {
+		resolver, ok := tenants[tenant]
+		if !ok {
+			return nil, errors.New("unknown tenant")
+		}
+		return resolver, nil
+	})
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, resolver, ok := TenantFromContext(r.Context())
+		if !ok {
+			t.Fatal("Tenant should be bound to the request context")
+		}
+		w.Write([]byte(tenant + ":" + MustResolve[TestInterface](resolver).GetValue()))
+	}))
+
+	tests := []struct {
+		tenant string
+		code   int
+		body   string
+	}{
+		{"globex", http.StatusOK, "globex:globex"},
+		{"initech", http.StatusNotFound, ""},
+		{"", http.StatusBadRequest, ""},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.tenant != "" {
+			req.Header.Set("X-Tenant", test.tenant)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if recorder.Code != test.code {
+			t.Errorf("Tenant %q: expected status %d, got %d", test.tenant, test.code, recorder.Code)
+		}
+		if test.body != "" && recorder.Body.String() != test.body {
+			t.Errorf("Tenant %q: expected body %q, got %q", test.tenant, test.body, recorder.Body.String())
+		}
+	}
+}
+
+func TestTenantFromHost(t *testing.T) {
+	extract := TenantFromHost()
+
+	req := httptest.NewRequest(http.MethodGet, "http://acme.example.com:8080/", nil)
+	if tenant, err := extract(req); err != nil || tenant != "acme" {
+		t.Errorf("Expected tenant acme, got %q (%v)", tenant, err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+	if _, err := extract(req); err == nil {
+		t.Error("Expected error for a host without a subdomain")
+	}
+}
@@ -0,0 +1,84 @@
+package inject
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// tracer records singleton construction spans in the Chrome trace event
+// format. Each top-level resolution gets its own track, so nested
+// constructions stack under the service that needed them.
+type tracer struct {
+	start  time.Time
+	mu     sync.Mutex
+	events []traceEvent
+}
+
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp float64           `json:"ts"`
+	Duration  float64           `json:"dur"`
+	Process   int               `json:"pid"`
+	Thread    int64             `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+func WithTracing() ContainerOption {
+	return func(c *Container) {
+		c.tracer = &tracer{start: time.Now()}
+	}
+}
+
+func (t *tracer) record(name, category string, track int64, start time.Time, err error) {
+	event := traceEvent{
+		Name:      name,
+		Category:  category,
+		Phase:     "X",
+		Timestamp: microseconds(start.Sub(t.start)),
+		Duration:  microseconds(time.Since(start)),
+		Process:   1,
+		Thread:    track,
+	}
+	if err != nil {
+		event.Args = map[string]string{"error": err.Error()}
+	}
+
+	t.mu.Lock()
+	t.events = append(t.events, event)
+	t.mu.Unlock()
+}
+
+func microseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
+
+// WriteChromeTrace writes the spans recorded by a WithTracing container as
+// trace event JSON, which chrome://tracing and Perfetto can open.
+func (c *Container) WriteChromeTrace(w io.Writer) error {
+	if c.tracer == nil {
+		return newError(ErrCodeInvalidArgument, nil, "tracing is not enabled; create the container with WithTracing")
+	}
+
+	c.tracer.mu.Lock()
+	events := make([]traceEvent, len(c.tracer.events))
+	copy(events, c.tracer.events)
+	c.tracer.mu.Unlock()
+
+	// Spans are recorded as they finish; order parents before the spans
+	// nested in them
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Timestamp != events[j].Timestamp {
+			return events[i].Timestamp < events[j].Timestamp
+		}
+		return events[i].Duration > events[j].Duration
+	})
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
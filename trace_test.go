@@ -0,0 +1,60 @@
+package inject
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWriteChromeTrace(t *testing.T) {
+	container := NewContainer(WithTracing())
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func(dep TestInterface) (*TestService, error) {
+		return nil, errors.New("startup failed")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	container.Resolve((*TestService)(nil))
+
+	var buf bytes.Buffer
+	if err := container.WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("Failed to write trace: %v", err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("Trace is not valid JSON: %v", err)
+	}
+	if len(trace.TraceEvents) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(trace.TraceEvents))
+	}
+
+	outer, inner := trace.TraceEvents[0], trace.TraceEvents[1]
+	if outer.Name != "inject.TestService" || inner.Name != "inject.TestInterface" {
+		t.Errorf("Spans should be ordered by start time, got %s and %s", outer.Name, inner.Name)
+	}
+	if outer.Phase != "X" || outer.Thread != inner.Thread {
+		t.Error("Nested constructions should be complete events on the same track")
+	}
+	if outer.Args["error"] == "" {
+		t.Error("Failed construction should record the error")
+	}
+}
+
+func TestWriteChromeTraceRequiresTracing(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewContainer().WriteChromeTrace(&buf); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT, got %v", err)
+	}
+}
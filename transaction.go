@@ -0,0 +1,155 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Transaction stages registrations so they are applied to the container
+// together or not at all. Each registration is validated when it is staged,
+// and Commit checks the dependencies of the whole set before installing it.
+type Transaction struct {
+	container *Container
+	mu        sync.Mutex
+	staged    []*ServiceDescriptor
+	generics  []stagedGeneric
+	finished  bool
+}
+
+var _ Registrar = (*Transaction)(nil)
+
+// Begin starts a transaction against the container. Nothing staged is visible
+// to resolution until Commit succeeds.
+func (c *Container) Begin() *Transaction {
+	return &Transaction{container: c}
+}
+
+func (tx *Transaction) Register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return tx.register(serviceType, factory, lifecycle, nil, opts)
+}
+
+func (tx *Transaction) RegisterSingleton(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return tx.Register(serviceType, factory, Singleton, opts...)
+}
+
+func (tx *Transaction) RegisterTransient(serviceType interface{}, factory interface{}, opts ...RegistrationOption) error {
+	return tx.Register(serviceType, factory, Transient, opts...)
+}
+
+func (tx *Transaction) RegisterFunc(factory interface{}, lifecycle Lifecycle, opts ...RegistrationOption) error {
+	return registerFunc(tx, factory, lifecycle, opts)
+}
+
+func (tx *Transaction) register(serviceType interface{}, factory interface{}, lifecycle Lifecycle, create func(*Container) (interface{}, error), opts []RegistrationOption) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.finished {
+		return newError(ErrCodeInvalidArgument, nil, "transaction has already been committed or rolled back")
+	}
+
+	tx.container.mu.RLock()
+	descriptor, err := tx.container.newDescriptor(serviceType, factory, lifecycle, create, opts)
+	tx.container.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	tx.staged = append(tx.staged, descriptor)
+	return nil
+}
+
+func (tx *Transaction) registerGeneric(key genericKey, template *genericTemplate) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.finished {
+		return newError(ErrCodeInvalidArgument, nil, "transaction has already been committed or rolled back")
+	}
+	if tx.container.noReflection {
+		return errGenericReflection(template.serviceType)
+	}
+	tx.generics = append(tx.generics, stagedGeneric{key, template})
+	return nil
+}
+
+type stagedGeneric struct {
+	key      genericKey
+	template *genericTemplate
+}
+
+// Commit installs every staged registration at once. Each staged factory's
+// dependencies must be registered in the container or staged in the same
+// transaction; otherwise Commit reports every missing dependency and the
+// container is left unchanged.
+func (tx *Transaction) Commit() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.finished {
+		return newError(ErrCodeInvalidArgument, nil, "transaction has already been committed or rolled back")
+	}
+
+	c := tx.container
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	staged := make(map[serviceKey]bool, len(tx.staged))
+	for _, descriptor := range tx.staged {
+		staged[serviceKey{serviceType: descriptor.ServiceType, name: descriptor.Name}] = true
+		for _, alias := range descriptor.aliases {
+			staged[serviceKey{serviceType: alias, name: descriptor.Name}] = true
+		}
+	}
+
+	var errs []error
+	for _, descriptor := range tx.staged {
+		for _, dep := range c.missingDependencies(reflect.TypeOf(descriptor.Factory), descriptor.methodExpr) {
+			if !staged[serviceKey{serviceType: dep}] && !tx.stagesGeneric(dep) {
+				errs = append(errs, newError(ErrCodeMissingDependency, descriptor.ServiceType, "factory for %s depends on unregistered service %s", descriptor.ServiceType.String(), dep.String()))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Error() < errs[j].Error()
+		})
+		return errors.Join(errs...)
+	}
+
+	for _, descriptor := range tx.staged {
+		c.install(descriptor)
+	}
+	for _, generic := range tx.generics {
+		c.installGeneric(generic.key, generic.template)
+	}
+	tx.staged, tx.generics = nil, nil
+	tx.finished = true
+	return nil
+}
+
+// stagesGeneric reports whether serviceType instantiates an open generic
+// staged in the transaction.
+func (tx *Transaction) stagesGeneric(serviceType reflect.Type) bool {
+	key, ok := genericKeyOf(serviceType)
+	if !ok {
+		return false
+	}
+	for _, generic := range tx.generics {
+		if generic.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Rollback discards every staged registration. It is safe to call after
+// Commit, so it can be deferred.
+func (tx *Transaction) Rollback() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	tx.staged, tx.generics = nil, nil
+	tx.finished = true
+}
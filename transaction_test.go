@@ -0,0 +1,103 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransactionCommit(t *testing.T) {
+	container := NewContainer()
+	tx := container.Begin()
+
+	// Registration order within a transaction does not matter
+	err := tx.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to stage service: %v", err)
+	}
+	err = RegisterSingletonType[TestInterface](tx, func(*Container) TestInterface {
+		return &TestImplementation{value: "staged"}
+	})
+	if err != nil {
+		t.Fatalf("Failed to stage interface: %v", err)
+	}
+
+	if container.Has((*TestService)(nil)) {
+		t.Error("Staged registrations should not be visible before Commit")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	service, err := container.Resolve((*TestService)(nil))
+	if err != nil {
+		t.Fatalf("Failed to resolve committed service: %v", err)
+	}
+	if service.(*TestService).GetDependency().GetValue() != "staged" {
+		t.Error("Committed registrations should be resolvable")
+	}
+
+	err = tx.RegisterTransient((*TestRepository)(nil), func() *TestRepository { return nil })
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT after Commit, got %v", err)
+	}
+}
+
+func TestTransactionCommitIsAllOrNothing(t *testing.T) {
+	container := NewContainer()
+	tx := container.Begin()
+	defer tx.Rollback()
+
+	err := tx.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to stage interface: %v", err)
+	}
+	err = tx.RegisterTransient((*TestService)(nil), func(dep TestInterface, repo *TestRepository) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to stage service: %v", err)
+	}
+
+	err = tx.Commit()
+	if ErrorCodeOf(err) != ErrCodeMissingDependency || !strings.Contains(err.Error(), "inject.TestRepository") {
+		t.Fatalf("Expected MISSING_DEPENDENCY for TestRepository, got %v", err)
+	}
+	if container.Has((*TestInterface)(nil)) || container.Has((*TestService)(nil)) {
+		t.Error("A failed Commit should leave the container unchanged")
+	}
+}
+
+func TestTransactionValidatesWhenStaging(t *testing.T) {
+	container := NewContainer()
+	tx := container.Begin()
+
+	err := tx.Register((*TestInterface)(nil), func() *TestRepository { return nil }, Transient)
+	if ErrorCodeOf(err) != ErrCodeTypeMismatch {
+		t.Errorf("Expected TYPE_MISMATCH, got %v", err)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	container := NewContainer()
+	tx := container.Begin()
+
+	err := tx.RegisterFunc(func() *TestRepository {
+		return &TestRepository{}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to stage func: %v", err)
+	}
+	tx.Rollback()
+
+	if err := tx.Commit(); ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT committing after Rollback, got %v", err)
+	}
+	if container.Has((*TestRepository)(nil)) {
+		t.Error("Rolled back registrations should not be installed")
+	}
+}
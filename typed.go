@@ -0,0 +1,58 @@
+package inject
+
+import "reflect"
+
+// typedFactory keeps the factory of a generics-registered service with its
+// static type, so transient resolution through MustResolve and TryResolve
+// can return the value without storing it in an interface{} first.
+type typedFactory[T any] struct {
+	factory func(*Container) T
+}
+
+// resolveTransient reports handled=false when the service needs the general
+// resolution path: missing or reflection-registered services, singletons and
+// registrations with a fallback.
+func resolveTransient[T any](c *Container) (result T, handled bool, err error) {
+	defer c.readLock()()
+
+	descriptor, exists := c.services[serviceKey{serviceType: reflect.TypeFor[T]()}]
+	if !exists || descriptor.Lifecycle != Transient || descriptor.fallback != nil || descriptor.decorators != nil {
+		return result, false, nil
+	}
+	// Resolve hooks and the closed check are left to the general path
+	if c.resolveHook.Load() != nil || c.closed.Load() {
+		return result, false, nil
+	}
+	typed, ok := descriptor.typed.(*typedFactory[T])
+	if !ok {
+		return result, false, nil
+	}
+	c.recordDependency(descriptor)
+	if err := c.checkCycle(descriptor, ""); err != nil {
+		return result, true, err
+	}
+
+	child, err := c.enter(descriptor)
+	if err != nil {
+		return result, true, err
+	}
+	defer child.frame.done.Store(true)
+
+	result, err = typed.call(child, descriptor)
+	return result, true, err
+}
+
+func (f *typedFactory[T]) call(c *Container, descriptor *ServiceDescriptor) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result = zero
+			err = newError(ErrCodeFactoryPanic, descriptor.ServiceType, "factory for %s panicked: %v", descriptor.ServiceType.String(), r)
+			c.factoryFailed(descriptor, err)
+		}
+	}()
+
+	result = f.factory(c)
+	descriptor.stats.created.Add(1)
+	return result, nil
+}
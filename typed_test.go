@@ -0,0 +1,73 @@
+package inject
+
+import (
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestTypedTransientResolve(t *testing.T) {
+	container := NewContainer()
+
+	calls := 0
+	err := RegisterTransientType[point](container, func(c *Container) point {
+		calls++
+		return point{X: calls, Y: 2}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	first := MustResolve[point](container)
+	second, ok := TryResolve[point](container)
+	if !ok {
+		t.Fatal("Failed to resolve service")
+	}
+	if first.X != 1 || second.X != 2 {
+		t.Errorf("Transient service should be created on every resolve, got %v and %v", first, second)
+	}
+	if container.Stats().Services[0].Created != 2 {
+		t.Error("Typed resolution should be counted in stats")
+	}
+}
+
+func TestTypedTransientResolveAvoidsBoxing(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterTransientType[point](container, func(c *Container) point {
+		return point{X: 1, Y: 2}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	boxed := testing.AllocsPerRun(100, func() {
+		container.Resolve((*point)(nil))
+	})
+	typed := testing.AllocsPerRun(100, func() {
+		MustResolve[point](container)
+	})
+	if typed >= boxed {
+		t.Errorf("Typed resolution should allocate less than Resolve, got %v and %v allocations", typed, boxed)
+	}
+}
+
+func TestTypedTransientResolvePanic(t *testing.T) {
+	container := NewContainer()
+
+	err := RegisterTransientType[point](container, func(c *Container) point {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if _, ok := TryResolve[point](container); ok {
+		t.Fatal("Expected resolution to fail")
+	}
+	if container.Stats().Services[0].Failures != 1 {
+		t.Error("Typed resolution should record factory panics")
+	}
+}
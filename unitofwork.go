@@ -0,0 +1,98 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// unitOfWork holds the commit and rollback hooks of a scope and the outcome
+// given to Complete.
+type unitOfWork struct {
+	commit    []func(ctx context.Context) error
+	rollback  []func(ctx context.Context) error
+	completed bool
+	err       error
+}
+
+// OnCommit adds a hook run when the scope is closed after Complete(nil),
+// such as committing a database transaction. Commit hooks run in the order
+// they were added, before the scoped instances are disposed. Services add
+// them through the scope of their context:
+//
+//	scope, _ := inject.ScopeFromContext(ctx)
+//	scope.OnCommit(func(ctx context.Context) error { return tx.Commit() })
+func (s *Scope) OnCommit(fn func(ctx context.Context) error) error {
+	return s.addHook(fn, true)
+}
+
+// OnRollback adds a hook run when the scope is closed after Complete with an
+// error, or without Complete being called, or when a commit hook fails.
+// Rollback hooks run in the reverse order they were added.
+func (s *Scope) OnRollback(fn func(ctx context.Context) error) error {
+	return s.addHook(fn, false)
+}
+
+func (s *Scope) addHook(fn func(ctx context.Context) error, commit bool) error {
+	if fn == nil {
+		return newError(ErrCodeInvalidArgument, nil, "hook must not be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return newError(ErrCodeScopeClosed, nil, "scope is closed")
+	}
+	if commit {
+		s.work.commit = append(s.work.commit, fn)
+	} else {
+		s.work.rollback = append(s.work.rollback, fn)
+	}
+	return nil
+}
+
+// Complete records the outcome of the scope's work: nil commits it when the
+// scope is closed, and an error rolls it back. Only the first call counts,
+// so a deferred Complete(err) does not undo an earlier decision.
+func (s *Scope) Complete(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.work.completed {
+		s.work.completed, s.work.err = true, err
+	}
+}
+
+// finish runs the commit hooks if the work completed without an error, and
+// the rollback hooks otherwise or once a commit hook fails.
+func (w unitOfWork) finish(ctx context.Context, fail func(serviceType reflect.Type, err error)) {
+	if len(w.commit) == 0 && len(w.rollback) == 0 {
+		return
+	}
+
+	if w.completed && w.err == nil {
+		committed := true
+		for _, fn := range w.commit {
+			if err := fn(ctx); err != nil {
+				fail(nil, fmt.Errorf("failed to commit scope: %w", err))
+				committed = false
+				break
+			}
+		}
+		if committed {
+			return
+		}
+	}
+
+	var errs []error
+	for i := len(w.rollback) - 1; i >= 0; i-- {
+		if err := w.rollback[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		fail(nil, fmt.Errorf("failed to roll back scope: %w", err))
+	}
+}
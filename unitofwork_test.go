@@ -0,0 +1,90 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func recordHooks(t *testing.T, scope *Scope, calls *[]string, names ...string) {
+	for _, name := range names {
+		err := scope.OnCommit(func(ctx context.Context) error {
+			*calls = append(*calls, "commit "+name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to add commit hook: %v", err)
+		}
+		err = scope.OnRollback(func(ctx context.Context) error {
+			*calls = append(*calls, "rollback "+name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to add rollback hook: %v", err)
+		}
+	}
+}
+
+func TestScopeComplete(t *testing.T) {
+	container := NewContainer()
+
+	var calls []string
+	committed := container.NewScope()
+	recordHooks(t, committed, &calls, "orders", "outbox")
+	committed.Complete(nil)
+	committed.Complete(errors.New("too late"))
+	if err := committed.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if strings.Join(calls, ",") != "commit orders,commit outbox" {
+		t.Errorf("Expected the commit hooks in order, got %v", calls)
+	}
+
+	calls = nil
+	failed := container.NewScope()
+	recordHooks(t, failed, &calls, "orders", "outbox")
+	failed.Complete(errors.New("payment declined"))
+	if err := failed.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if strings.Join(calls, ",") != "rollback outbox,rollback orders" {
+		t.Errorf("Expected the rollback hooks in reverse order, got %v", calls)
+	}
+
+	calls = nil
+	abandoned := container.NewScope()
+	recordHooks(t, abandoned, &calls, "orders")
+	if err := abandoned.Close(); err != nil {
+		t.Fatalf("Failed to close scope: %v", err)
+	}
+	if strings.Join(calls, ",") != "rollback orders" {
+		t.Errorf("Expected a scope closed without Complete to roll back, got %v", calls)
+	}
+	if err := abandoned.OnCommit(func(ctx context.Context) error { return nil }); ErrorCodeOf(err) != ErrCodeScopeClosed {
+		t.Errorf("Expected SCOPE_CLOSED adding a hook to a closed scope, got %v", err)
+	}
+}
+
+func TestScopeCommitFailureRollsBack(t *testing.T) {
+	container := NewContainer()
+
+	var calls []string
+	scope := container.NewScope()
+	recordHooks(t, scope, &calls, "orders")
+	err := scope.OnCommit(func(ctx context.Context) error {
+		return errCloseFailed
+	})
+	if err != nil {
+		t.Fatalf("Failed to add commit hook: %v", err)
+	}
+	recordHooks(t, scope, &calls, "outbox")
+	scope.Complete(nil)
+
+	if err := scope.Close(); !errors.Is(err, errCloseFailed) {
+		t.Errorf("Expected the commit failure, got %v", err)
+	}
+	if strings.Join(calls, ",") != "commit orders,rollback outbox,rollback orders" {
+		t.Errorf("Expected the rollback hooks after a failed commit, got %v", calls)
+	}
+}
@@ -0,0 +1,70 @@
+package inject
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+func WithDependencyChecks() ContainerOption {
+	return func(c *Container) {
+		c.checkDependencies = true
+	}
+}
+
+// Validate reports every registration whose factory depends on a service
+// that is not registered. Unlike WithDependencyChecks it does not care about
+// registration order, so it is meant to run once all wiring is done.
+func (c *Container) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var errs []error
+	for _, descriptor := range append(c.sortedDescriptors(), c.genericInstances()...) {
+		for _, dep := range c.missingDependencies(reflect.TypeOf(descriptor.Factory), descriptor.methodExpr) {
+			errs = append(errs, newError(ErrCodeMissingDependency, descriptor.ServiceType, "factory for %s depends on unregistered service %s", descriptor.ServiceType.String(), dep.String()))
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errors.Join(errs...)
+}
+
+func (c *Container) missingDependencies(factoryType reflect.Type, methodExpr bool) []reflect.Type {
+	var missing []reflect.Type
+	for i := 0; i < factoryType.NumIn(); i++ {
+		argType := factoryType.In(i)
+		if argType == reflect.TypeOf((*Container)(nil)) || argType == contextType {
+			continue
+		}
+		if i == 0 && methodExpr {
+			argType = c.receiverKey(argType)
+		}
+		if isParamStruct(argType) {
+			for _, f := range paramFields(argType) {
+				if !f.optional && !f.registered(c) {
+					missing = append(missing, f.serviceType)
+				}
+			}
+			continue
+		}
+		if target, ok := lazyKey(argType); ok {
+			argType = target
+		}
+		if _, exists := c.services[serviceKey{serviceType: argType}]; exists {
+			continue
+		}
+		if _, exists := c.channelKey(argType); exists {
+			continue
+		}
+		if _, exists := c.collectionKey(argType); exists {
+			continue
+		}
+		if _, exists := c.genericKeyRegistered(argType); !exists {
+			missing = append(missing, argType)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,78 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependencyChecksRejectUnknownDependencies(t *testing.T) {
+	container := NewContainer(WithDependencyChecks())
+
+	err := container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err == nil {
+		t.Fatal("Expected error when registering a factory with an unregistered dependency")
+	}
+	if !strings.Contains(err.Error(), "inject.TestInterface") {
+		t.Errorf("Error should name the missing dependency, got '%s'", err.Error())
+	}
+	if container.Has((*TestService)(nil)) {
+		t.Error("Rejected registration should not be stored")
+	}
+
+	err = container.Register((*TestInterface)(nil), func(c *Container) TestInterface {
+		return &TestImplementation{value: "dep"}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Container parameters should always be accepted: %v", err)
+	}
+
+	err = container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Registration should succeed once dependencies are registered: %v", err)
+	}
+}
+
+func TestDependencyChecksDisabledByDefault(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Errorf("Registration order should not matter without dependency checks: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterTransient((*TestService)(nil), func(dep TestInterface) *TestService {
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = container.Validate()
+	if err == nil {
+		t.Fatal("Validate should report missing dependencies")
+	}
+	if !strings.Contains(err.Error(), "inject.TestService") || !strings.Contains(err.Error(), "inject.TestInterface") {
+		t.Errorf("Validate error should name the service and the dependency, got '%s'", err.Error())
+	}
+
+	err = container.Register((*TestInterface)(nil), func() TestInterface {
+		return &TestImplementation{value: "late"}
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("Failed to register interface: %v", err)
+	}
+
+	if err := container.Validate(); err != nil {
+		t.Errorf("Validate should pass once all dependencies are registered, got %v", err)
+	}
+}
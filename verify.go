@@ -0,0 +1,69 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Verify is a preflight check for CI: it applies fakes (typically
+// registrations replacing clients of external endpoints), runs Validate,
+// builds every singleton with Warmup and then drops the instances again with
+// ResetSingletons. It modifies c, so pass a container built only for
+// verification.
+func Verify(ctx context.Context, c *Container, fakes ...func(*Container) error) error {
+	var errs []error
+	for _, fake := range fakes {
+		if err := fake(c); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply fake: %w", err))
+		}
+	}
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Warmup(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.ResetSingletons(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyOrExit runs Verify, prints every problem to stderr and exits with
+// status 1 if there were any.
+func VerifyOrExit(ctx context.Context, c *Container, fakes ...func(*Container) error) {
+	if code := verifyReport(ctx, c, os.Stderr, fakes); code != 0 {
+		os.Exit(code)
+	}
+}
+
+func verifyReport(ctx context.Context, c *Container, w io.Writer, fakes []func(*Container) error) int {
+	err := Verify(ctx, c, fakes...)
+	if err == nil {
+		fmt.Fprintf(w, "wiring verified: %d services\n", len(c.GetServiceTypes()))
+		return 0
+	}
+
+	problems := flattenErrors(err)
+	fmt.Fprintf(w, "wiring verification failed with %d problems:\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(w, "  - %v\n", problem)
+	}
+	return 1
+}
+
+// flattenErrors expands errors.Join trees into their leaves.
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, flattenErrors(e)...)
+	}
+	return errs
+}
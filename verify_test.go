@@ -0,0 +1,77 @@
+package inject
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() (TestInterface, error) {
+		return nil, errors.New("dial tcp: connection refused")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	built := 0
+	err = container.RegisterSingleton((*TestService)(nil), func(dep TestInterface) *TestService {
+		built++
+		return &TestService{dependency: dep}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	fake := func(c *Container) error {
+		return c.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+			return &TestImplementation{value: "fake"}
+		})
+	}
+
+	var out bytes.Buffer
+	if code := verifyReport(context.Background(), container, &out, []func(*Container) error{fake}); code != 0 {
+		t.Fatalf("Expected verification to pass with fakes, got:\n%s", out.String())
+	}
+	if built != 1 || !strings.Contains(out.String(), "wiring verified: 2 services") {
+		t.Errorf("Verification should build every singleton, output:\n%s", out.String())
+	}
+	if service, _ := container.Resolve((*TestService)(nil)); built != 2 || service == nil {
+		t.Error("Instances built during verification should be dropped")
+	}
+}
+
+func TestVerifyReportsAllProblems(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() (TestInterface, error) {
+		return nil, errors.New("dial tcp: connection refused")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestService)(nil), func(dep *TestImplementation) *TestService {
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	var out bytes.Buffer
+	if code := verifyReport(context.Background(), container, &out, nil); code != 1 {
+		t.Fatal("Expected verification to fail")
+	}
+	output := out.String()
+	for _, expected := range []string{
+		"failed with 2 problems",
+		"depends on unregistered service *inject.TestImplementation",
+		"connection refused",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
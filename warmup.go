@@ -0,0 +1,59 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WithEager marks a singleton to be built by Start rather than on first
+// use, for connection pools and caches that should be ready at boot.
+func WithEager() RegistrationOption {
+	return func(descriptor *ServiceDescriptor) {
+		descriptor.eager = true
+	}
+}
+
+// Start builds the singletons registered WithEager. Call it once
+// registration is complete; the rest stay lazy.
+func (c *Container) Start(ctx context.Context) error {
+	return c.Warmup(ctx, func(descriptor *ServiceDescriptor) bool {
+		return descriptor.eager
+	})
+}
+
+// Warmup builds the singletons matching all filters, or every singleton when
+// none are given, so they are ready before the first request. It stops early
+// when ctx ends and returns the construction failures joined together.
+func (c *Container) Warmup(ctx context.Context, filters ...ServiceFilter) error {
+	descriptors := c.descriptors(append(filters[:len(filters):len(filters)], ByLifecycle(Singleton)))
+
+	var errs []error
+	for i, descriptor := range descriptors {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		event := InitEvent{
+			ServiceType: descriptor.ServiceType,
+			Phase:       InitStarted,
+			Index:       i + 1,
+			Total:       len(descriptors),
+		}
+		c.reportProgress(event)
+
+		start := time.Now()
+		c.mu.RLock()
+		_, err := c.resolveDescriptor(descriptor)
+		c.mu.RUnlock()
+
+		event.Phase, event.Duration, event.Err = InitCompleted, time.Since(start), err
+		if err != nil {
+			event.Phase = InitFailed
+			errs = append(errs, fmt.Errorf("warming up %s: %w", descriptor.displayName(), err))
+		}
+		c.reportProgress(event)
+	}
+	return errors.Join(errs...)
+}
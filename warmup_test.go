@@ -0,0 +1,135 @@
+package inject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWarmupByTag(t *testing.T) {
+	container := NewContainer()
+
+	var built []string
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		built = append(built, "critical")
+		return &TestImplementation{}
+	}, WithTags("critical"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func() *TestService {
+		built = append(built, "optional")
+		return &TestService{}
+	}, WithTags("reporting"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		built = append(built, "transient")
+		return &TestImplementation{}
+	}, WithTags("critical"))
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if err := container.Warmup(context.Background(), ByTag("critical")); err != nil {
+		t.Fatalf("Failed to warm up: %v", err)
+	}
+	if strings.Join(built, ",") != "critical" {
+		t.Errorf("Only tagged singletons should be built, got %v", built)
+	}
+
+	if err := container.Warmup(context.Background()); err != nil {
+		t.Fatalf("Failed to warm up: %v", err)
+	}
+	if strings.Join(built, ",") != "critical,optional" {
+		t.Errorf("Warmup without filters should build every singleton once, got %v", built)
+	}
+}
+
+func TestWarmupErrors(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterSingleton((*TestInterface)(nil), func() (TestInterface, error) {
+		return nil, errors.New("cache unavailable")
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	err = container.Warmup(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "warming up inject.TestInterface") {
+		t.Errorf("Expected warmup failure, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := container.Warmup(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected warmup to stop on a cancelled context, got %v", err)
+	}
+}
+
+func TestStartBuildsEagerSingletons(t *testing.T) {
+	container := NewContainer()
+
+	var built []string
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		built = append(built, "pool")
+		return &TestImplementation{}
+	}, WithEager())
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+	err = container.RegisterSingleton((*TestService)(nil), func() *TestService {
+		built = append(built, "lazy")
+		return &TestService{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	if err := container.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	if strings.Join(built, ",") != "pool" {
+		t.Errorf("Start should build only eager singletons, got %v", built)
+	}
+
+	err = container.RegisterTransient((*TestImplementation)(nil), func() *TestImplementation {
+		return &TestImplementation{}
+	}, WithEager())
+	if ErrorCodeOf(err) != ErrCodeInvalidArgument {
+		t.Errorf("Expected INVALID_ARGUMENT for an eager transient, got %v", err)
+	}
+}
+
+func TestWarmupNamedAndGroupSingletons(t *testing.T) {
+	container := NewContainer()
+
+	var built []string
+	err := container.RegisterSingleton((*TestInterface)(nil), func() TestInterface {
+		built = append(built, "named")
+		return &TestImplementation{}
+	}, WithName("primary"), WithEager())
+	if err != nil {
+		t.Fatalf("Failed to register named service: %v", err)
+	}
+	err = RegisterGroup[TestInterface](container, "routes", func(c *Container) TestInterface {
+		built = append(built, "group")
+		return &TestImplementation{}
+	}, WithEager())
+	if err != nil {
+		t.Fatalf("Failed to register group member: %v", err)
+	}
+
+	if err := container.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	if len(built) != 2 {
+		t.Errorf("Start should build named and group singletons, got %v", built)
+	}
+	if err := container.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup should resolve named and group singletons: %v", err)
+	}
+}